@@ -0,0 +1,283 @@
+package manta
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/dotabuff/manta/dota"
+)
+
+// Returned by a FragmentSource when a fragment isn't available, either
+// because the broadcast has ended or it hasn't been produced yet.
+var ErrFragmentNotFound = errors.New("fragment not found")
+
+// Returned by the methods of the embedded Parser that don't apply to a
+// broadcast, which is parsed as fragments arrive and can't be seeked within.
+var ErrNotSupported = errors.New("not supported by a broadcast parser")
+
+// Describes the state of a broadcast, as returned by its sync endpoint.
+type BroadcastSync struct {
+	// The tick of the most recent fragment.
+	Tick int `json:"tick"`
+
+	// The most recent fragment with a full snapshot, to start parsing from.
+	Fragment int `json:"fragment"`
+
+	// The fragment containing the signon messages.
+	SignupFragment int `json:"signup_fragment"`
+
+	// The number of ticks per second.
+	TicksPerSecond int `json:"tps"`
+}
+
+// A FragmentSource provides the fragments of a broadcast. Fragments are
+// numbered and come in three kinds: "start" holds the signon messages, "full"
+// a full snapshot and "delta" the changes since the previous fragment.
+type FragmentSource interface {
+	// Returns the current state of the broadcast.
+	Sync(ctx context.Context) (*BroadcastSync, error)
+
+	// Opens the given fragment for reading. Returns ErrFragmentNotFound if
+	// the fragment isn't available.
+	Fragment(ctx context.Context, n int, kind string) (io.ReadCloser, error)
+}
+
+// A FragmentSource fetching fragments from a broadcast relay over HTTP, laid
+// out as {URL}/sync and {URL}/{n}/{kind}.
+type HTTPFragmentSource struct {
+	// The client to use. Defaults to http.DefaultClient.
+	Client *http.Client
+
+	// The base URL of the broadcast.
+	URL string
+}
+
+func (s *HTTPFragmentSource) Sync(ctx context.Context) (*BroadcastSync, error) {
+	body, err := s.get(ctx, s.URL+"/sync")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	sync := &BroadcastSync{}
+	if err := json.NewDecoder(body).Decode(sync); err != nil {
+		return nil, _errorf("unable to decode broadcast sync: %w", err)
+	}
+	return sync, nil
+}
+
+func (s *HTTPFragmentSource) Fragment(ctx context.Context, n int, kind string) (io.ReadCloser, error) {
+	return s.get(ctx, s.URL+"/"+strconv.Itoa(n)+"/"+kind)
+}
+
+// Performs a GET request, returning the response body.
+func (s *HTTPFragmentSource) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		resp.Body.Close()
+		return nil, ErrFragmentNotFound
+	}
+
+	resp.Body.Close()
+	return nil, _errorf("unexpected status fetching %s: %s", url, resp.Status)
+}
+
+// A FragmentSource reading fragments saved to a directory, laid out in the
+// same way as HTTPFragmentSource. Useful for tests and archived broadcasts.
+type DirFragmentSource string
+
+func (s DirFragmentSource) Sync(ctx context.Context) (*BroadcastSync, error) {
+	f, err := os.Open(filepath.Join(string(s), "sync"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sync := &BroadcastSync{}
+	if err := json.NewDecoder(f).Decode(sync); err != nil {
+		return nil, _errorf("unable to decode broadcast sync: %w", err)
+	}
+	return sync, nil
+}
+
+func (s DirFragmentSource) Fragment(ctx context.Context, n int, kind string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(string(s), strconv.Itoa(n), kind))
+	if os.IsNotExist(err) {
+		return nil, ErrFragmentNotFound
+	}
+	return f, err
+}
+
+// A BroadcastParser parses a live broadcast delivered as a series of
+// fragments. It embeds a Parser, so callbacks and entity handlers are
+// registered in the same way as for a replay. Parsing a range of ticks and
+// seeking aren't supported, and return ErrNotSupported.
+type BroadcastParser struct {
+	*Parser
+
+	// Determines how long to wait before asking for a fragment again when it
+	// isn't available yet. When zero, parsing stops at the first missing
+	// fragment, which suits broadcasts that have ended.
+	PollInterval time.Duration
+
+	source FragmentSource
+}
+
+// Create a new BroadcastParser reading fragments from the given source.
+func NewBroadcastParser(source FragmentSource) *BroadcastParser {
+	return &BroadcastParser{
+		Parser: newParser(),
+		source: source,
+	}
+}
+
+// Start parsing the broadcast. Will stop processing new events after Stop()
+// is called, or the broadcast ends.
+func (b *BroadcastParser) Start() error {
+	return b.StartContext(context.Background())
+}
+
+// Start parsing the broadcast, checking for cancellation of the given context
+// between messages and while waiting for fragments. Returns ctx.Err() if the
+// context is cancelled before parsing completes.
+func (b *BroadcastParser) StartContext(ctx context.Context) error {
+	defer b.afterStop()
+
+	sync, err := b.source.Sync(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Start with the signon messages, then a full snapshot, which are both
+	// required. Then follow on with deltas for as long as they keep coming.
+	if err := b.requireFragment(ctx, sync.SignupFragment, "start"); err != nil {
+		return err
+	}
+	if err := b.requireFragment(ctx, sync.Fragment, "full"); err != nil {
+		return err
+	}
+
+	for n := sync.Fragment; !b.isStopped(); {
+		ok, err := b.fetchFragment(ctx, n, "delta")
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			n++
+			continue
+		}
+
+		// The fragment isn't available, wait for it if we're configured to.
+		if b.PollInterval == 0 {
+			return b.finishTick()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(b.PollInterval):
+		}
+	}
+
+	return nil
+}
+
+// Not supported by a broadcast, returns ErrNotSupported.
+func (b *BroadcastParser) StartRange(fromTick, toTick uint32) error {
+	return ErrNotSupported
+}
+
+// Not supported by a broadcast, returns ErrNotSupported.
+func (b *BroadcastParser) SeekToTick(tick uint32) error {
+	return ErrNotSupported
+}
+
+// Not supported by a broadcast, returns ErrNotSupported.
+func (b *BroadcastParser) BuildIndex() (*ReplayIndex, error) {
+	return nil, ErrNotSupported
+}
+
+// Fetches and processes a single fragment, reporting whether or not it was
+// available.
+func (b *BroadcastParser) fetchFragment(ctx context.Context, n int, kind string) (bool, error) {
+	r, err := b.source.Fragment(ctx, n, kind)
+	if err == ErrFragmentNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	return true, b.processFragment(ctx, r, kind == "full")
+}
+
+// Fetches and processes a fragment, returning an error if it isn't available.
+func (b *BroadcastParser) requireFragment(ctx context.Context, n int, kind string) error {
+	ok, err := b.fetchFragment(ctx, n, kind)
+	if err == nil && !ok {
+		err = _errorf("%w: %d/%s", ErrFragmentNotFound, n, kind)
+	}
+	return err
+}
+
+// Processes the outer messages of a broadcast fragment. Full packets in a full
+// fragment replace the current string tables and entities. Errors report
+// offsets within the fragment.
+func (p *Parser) processFragment(ctx context.Context, r io.Reader, full bool) error {
+	p.stream = newStream(r)
+
+	for !p.isStopped() && !p.stream.atEOF() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		offset := p.stream.pos
+		msg, err := p.readOuterMessage()
+		if err != nil {
+			return p.readError(err, offset)
+		}
+
+		if full && msg.typeId == int32(dota.EDemoCommands_DEM_FullPacket) {
+			err = p.applyFullPacket(msg, offset)
+		} else {
+			err = p.processOuterMessage(msg, offset)
+		}
+		if err != nil {
+			return err
+		}
+
+		// The broadcast has ended.
+		if msg.typeId == int32(dota.EDemoCommands_DEM_Stop) {
+			p.Stop()
+			return p.finishTick()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package manta
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/stretchr/testify/assert"
+)
+
+// Writes a broadcast with signon in fragment 0, a full snapshot in fragment
+// 2 and deltas in fragments 2 and 3 to a temporary directory.
+func makeTestBroadcast(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "manta")
+	assert.NoError(t, err)
+
+	fragments := map[string][]byte{
+		"sync": []byte(`{"tick":30,"fragment":2,"signup_fragment":0,"tps":30}`),
+		"0/start": makeTestReplay(
+			testOuterMessage{dota.EDemoCommands_DEM_SignonPacket, 0, &dota.CDemoPacket{}},
+			testOuterMessage{dota.EDemoCommands_DEM_SyncTick, 0, &dota.CDemoSyncTick{}},
+		)[16:],
+		"1/delta": makeTestReplay(
+			testOuterMessage{dota.EDemoCommands_DEM_Packet, 10, &dota.CDemoPacket{}},
+		)[16:],
+		"2/full": makeTestReplay(
+			testOuterMessage{dota.EDemoCommands_DEM_FullPacket, 15, makeTestFullPacket("a", "b")},
+		)[16:],
+		"2/delta": makeTestReplay(
+			testOuterMessage{dota.EDemoCommands_DEM_Packet, 20, &dota.CDemoPacket{}},
+		)[16:],
+		"3/delta": makeTestReplay(
+			testOuterMessage{dota.EDemoCommands_DEM_Packet, 30, &dota.CDemoPacket{}},
+		)[16:],
+	}
+
+	for name, buf := range fragments {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, ioutil.WriteFile(path, buf, 0644))
+	}
+
+	return dir
+}
+
+// Creates a broadcast parser with a "test" string table that full packets
+// restore, recording the ticks of packets processed.
+func newTestBroadcastParser(source FragmentSource) (*BroadcastParser, *[]uint32) {
+	parser := NewBroadcastParser(source)
+	parser.StringTables.Tables[0] = &StringTable{name: "test", Items: make(map[int32]*StringTableItem)}
+	parser.StringTables.NameIndex["test"] = 0
+
+	ticks := make([]uint32, 0)
+	parser.Callbacks.OnCDemoPacket(func(m *dota.CDemoPacket) error {
+		ticks = append(ticks, parser.Tick)
+		return nil
+	})
+
+	return parser, &ticks
+}
+
+func TestBroadcastParserDir(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := makeTestBroadcast(t)
+	defer os.RemoveAll(dir)
+
+	parser, ticks := newTestBroadcastParser(DirFragmentSource(dir))
+
+	signon := false
+	parser.Callbacks.OnCDemoSyncTick(func(m *dota.CDemoSyncTick) error {
+		signon = true
+		return nil
+	})
+
+	stopped := false
+	parser.AfterStopCallback = func() {
+		stopped = true
+	}
+
+	assert.NoError(parser.Start())
+	assert.True(signon)
+	assert.True(stopped)
+	assert.Equal([]uint32{20, 30}, *ticks)
+	assert.Equal(uint32(30), parser.Tick)
+
+	table, _ := parser.StringTables.GetTableByName("test")
+	assert.Len(table.Items, 2)
+}
+
+func TestBroadcastParserHTTP(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := makeTestBroadcast(t)
+	defer os.RemoveAll(dir)
+
+	server := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer server.Close()
+
+	parser, ticks := newTestBroadcastParser(&HTTPFragmentSource{URL: server.URL})
+	assert.NoError(parser.Start())
+	assert.Equal([]uint32{20, 30}, *ticks)
+}
+
+func TestBroadcastParserPoll(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := makeTestBroadcast(t)
+	defer os.RemoveAll(dir)
+
+	// Wait for fragments that never arrive until the context is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	parser, ticks := newTestBroadcastParser(DirFragmentSource(dir))
+	parser.PollInterval = 5 * time.Millisecond
+	assert.Equal(context.DeadlineExceeded, parser.StartContext(ctx))
+	assert.Equal([]uint32{20, 30}, *ticks)
+}
+
+func TestBroadcastParserMissingStart(t *testing.T) {
+	assert := assert.New(t)
+
+	dir := makeTestBroadcast(t)
+	defer os.RemoveAll(dir)
+	assert.NoError(os.Remove(filepath.Join(dir, "0", "start")))
+
+	parser := NewBroadcastParser(DirFragmentSource(dir))
+	assert.True(errors.Is(parser.Start(), ErrFragmentNotFound))
+}
+
+func TestBroadcastParserNotSupported(t *testing.T) {
+	assert := assert.New(t)
+
+	parser := NewBroadcastParser(DirFragmentSource(os.TempDir()))
+	assert.Equal(ErrNotSupported, parser.StartRange(0, 10))
+	assert.Equal(ErrNotSupported, parser.SeekToTick(10))
+	_, err := parser.BuildIndex()
+	assert.Equal(ErrNotSupported, err)
+}
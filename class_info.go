@@ -36,49 +36,54 @@ func (p *Parser) onCDemoClassInfo(m *dota.CDemoClassInfo) error {
 		p.ClassInfo[c.GetClassId()] = c.GetNetworkName()
 
 		if _, ok := p.serializers[c.GetNetworkName()]; !ok {
-			_panicf("unable to find table for class %d (%s)", c.GetClassId, c.GetNetworkName())
+			return _errorf("unable to find table for class %d (%s)", c.GetClassId(), c.GetNetworkName())
 		}
 	}
 
 	// Remember that we've gotten the class info
 	p.hasClassInfo = true
 
-	// Try to update the instancebaseline
-	p.updateInstanceBaseline()
+	// Match entity handlers to the classes
+	p.buildEntityClassTable()
 
-	return nil
+	// Try to update the instancebaseline
+	return p.updateInstanceBaseline()
 }
 
 // Updates the state of instancebaseline
-func (p *Parser) updateInstanceBaseline() {
+func (p *Parser) updateInstanceBaseline() error {
 	// We can't update the instancebaseline until we have class info.
 	if !p.hasClassInfo {
-		return
+		return nil
 	}
 
 	stringTable, ok := p.StringTables.GetTableByName("instancebaseline")
 	if !ok {
 		_debugf("skipping updateInstanceBaseline: no instancebaseline string table")
-		return
+		return nil
 	}
 
 	// Iterate through instancebaseline table items
 	for _, item := range stringTable.Items {
-		p.updateInstanceBaselineItem(item)
+		if err := p.updateInstanceBaselineItem(item); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
-func (p *Parser) updateInstanceBaselineItem(item *StringTableItem) {
+func (p *Parser) updateInstanceBaselineItem(item *StringTableItem) error {
 	// Get the class id for the string table item
 	classId, err := atoi32(item.Key)
 	if err != nil {
-		_panicf("invalid instancebaseline key '%s': %s", item.Key, err)
+		return _errorf("invalid instancebaseline key '%s': %w", item.Key, err)
 	}
 
 	// Get the class name
 	className, ok := p.ClassInfo[classId]
 	if !ok {
-		_panicf("unable to find class info for instancebaseline key %d", classId)
+		return _errorf("unable to find class info for instancebaseline key %d", classId)
 	}
 
 	// Create an entry in the map if needed
@@ -89,7 +94,7 @@ func (p *Parser) updateInstanceBaselineItem(item *StringTableItem) {
 	// Get the send table associated with the class.
 	serializer, ok := p.serializers[className]
 	if !ok {
-		_panicf("unable to find send table %s for instancebaseline key %d", className, classId)
+		return _errorf("unable to find send table %s for instancebaseline key %d", className, classId)
 	}
 
 	// Uncomment to dump fixtures
@@ -100,11 +105,17 @@ func (p *Parser) updateInstanceBaselineItem(item *StringTableItem) {
 	if len(item.Value) > 0 {
 		_debugfl(1, "Parsing entity baseline %v", serializer[0].Name)
 		r := NewReader(item.Value)
-		p.ClassBaselines[classId] = ReadProperties(r, serializer[0])
+		props, err := ReadProperties(r, serializer[0])
+		if err != nil {
+			return _errorf("unable to read baseline %s: %w", serializer[0].Name, err)
+		}
+		p.ClassBaselines[classId] = props
 
 		// Inline test the baselines
-		if testLevel >= 1 && r.remBits() > 8 {
-			_panicf("Too many bits remaining in baseline %v, %v", serializer[0].Name, r.remBits())
+		if p.StrictBaselines && r.remBits() > 8 {
+			return _errorf("Too many bits remaining in baseline %v, %v", serializer[0].Name, r.remBits())
 		}
 	}
+
+	return nil
 }
@@ -0,0 +1,50 @@
+package manta
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Leading bytes of the compression formats replays are commonly wrapped in.
+var magicBzip2 = []byte{'B', 'Z', 'h'}
+var magicGzip = []byte{0x1f, 0x8b}
+var magicZstd = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// Wraps the given reader in a decompressor if its leading bytes identify
+// a bzip2, gzip or zstd stream. Uncompressed input is passed through as is.
+// The returned ReadCloser releases any resources held by the decompressor,
+// but does not close the underlying reader. The returned bool reports whether
+// or not the input was compressed.
+func newDecompressor(r io.Reader) (io.ReadCloser, bool, error) {
+	br := bufio.NewReader(r)
+
+	// A short read just means the input is too small to be compressed, let
+	// the magic check in the parser deal with it.
+	head, err := br.Peek(4)
+	if err != nil && err != io.EOF {
+		return nil, false, err
+	}
+
+	switch format, _ := DetectFormat(head); format {
+	case Format_Bzip2:
+		return ioutil.NopCloser(bzip2.NewReader(br)), true, nil
+
+	case Format_Gzip:
+		gr, err := gzip.NewReader(br)
+		return gr, true, err
+
+	case Format_Zstd:
+		zr, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, false, err
+		}
+		return zr.IOReadCloser(), true, nil
+	}
+
+	return ioutil.NopCloser(br), false, nil
+}
@@ -0,0 +1,83 @@
+package manta
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCompressedReplays(t *testing.T) {
+	assert := assert.New(t)
+
+	raw := makeTestReplayMinimal()
+
+	gz := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(gz)
+	gw.Write(raw)
+	gw.Close()
+
+	zs := bytes.NewBuffer(nil)
+	zw, _ := zstd.NewWriter(zs)
+	zw.Write(raw)
+	zw.Close()
+
+	// The standard library can't write bzip2, so this is makeTestReplayMinimal()
+	// compressed with the bzip2 tool.
+	bz, err := ioutil.ReadFile("fixtures/compression/minimal.dem.bz2")
+	assert.NoError(err)
+
+	scenarios := []struct {
+		name string
+		buf  []byte
+	}{
+		{"raw", raw},
+		{"bzip2", bz},
+		{"gzip", gz.Bytes()},
+		{"zstd", zs.Bytes()},
+	}
+
+	for _, s := range scenarios {
+		parser, err := NewParser(s.buf)
+		if !assert.NoError(err, s.name) {
+			continue
+		}
+		assert.NoError(parser.Start(), s.name)
+		assert.Equal(uint32(100), parser.Tick, s.name)
+	}
+}
+
+func TestParseCompressedReplayFromFile(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "manta")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	gz := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(gz)
+	gw.Write(makeTestReplayMinimal())
+	gw.Close()
+
+	path := filepath.Join(dir, "replay.dem.gz")
+	assert.NoError(ioutil.WriteFile(path, gz.Bytes(), 0644))
+
+	parser, err := NewParserFromFile(path)
+	assert.NoError(err)
+	assert.NoError(parser.Start())
+	assert.Equal(uint32(100), parser.Tick)
+}
+
+func TestParseBzip2ReplayFromFile(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParserFromFile("fixtures/compression/minimal.dem.bz2")
+	assert.NoError(err)
+	assert.NoError(parser.Start())
+	assert.Equal(uint32(100), parser.Tick)
+}
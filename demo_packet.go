@@ -2,47 +2,63 @@ package manta
 
 import (
 	"sort"
+	"time"
 
 	"github.com/dotabuff/manta/dota"
 )
 
 // A message that has been read from an outerMessage but not yet processed.
 type pendingMessage struct {
-	tick uint32
-	t    int32
-	buf  []byte
+	tick     uint32
+	t        int32
+	priority int
+	buf      []byte
 }
 
-// Calculates the priority of the message. Lower is more important.
-func (m *pendingMessage) priority() int {
-	switch m.t {
-	case
-		// These messages provide context needed for the rest of the tick
-		// and should have the highest priority.
-		int32(dota.NET_Messages_net_Tick),
-		int32(dota.SVC_Messages_svc_CreateStringTable),
-		int32(dota.SVC_Messages_svc_UpdateStringTable),
-		int32(dota.NET_Messages_net_SpawnGroup_Load):
-		return -10
-
-	case
-		// These messages benefit from having context but may also need to
-		// provide context in terms of delta updates.
-		int32(dota.SVC_Messages_svc_PacketEntities):
-		return 5
-
-	case
-		// These messages benefit from having as much context as possible and
-		// should have the lowest priority.
-		int32(dota.EBaseGameEvents_GE_Source1LegacyGameEvent):
-		return 10
+// The default priorities of inner packet types. Lower is more important, and
+// types not listed have a priority of 0.
+var defaultMessagePriorities = map[int32]int{
+	// These messages provide context needed for the rest of the tick
+	// and should have the highest priority.
+	int32(dota.NET_Messages_net_Tick):              -10,
+	int32(dota.SVC_Messages_svc_CreateStringTable): -10,
+	int32(dota.SVC_Messages_svc_UpdateStringTable): -10,
+	int32(dota.NET_Messages_net_SpawnGroup_Load):   -10,
+
+	// These messages benefit from having context but may also need to
+	// provide context in terms of delta updates.
+	int32(dota.SVC_Messages_svc_PacketEntities): 5,
+
+	// These messages benefit from having as much context as possible and
+	// should have the lowest priority.
+	int32(dota.EBaseGameEvents_GE_Source1LegacyGameEvent): 10,
+}
+
+// Sets the priority of an inner packet type, which determines the order that
+// messages in the same packet are dispatched in. Lower is more important, and
+// messages with the same priority are dispatched in the order they were
+// received. By default ticks, string tables and spawn groups come first (-10),
+// followed by other messages (0), then entities (5) and game events (10). For
+// example, to see user messages after entity updates:
+//
+//	p.SetMessagePriority(int32(dota.EDotaUserMessages_DOTA_UM_ChatEvent), 6)
+func (p *Parser) SetMessagePriority(msgType int32, prio int) {
+	if p.messagePriorities == nil {
+		p.messagePriorities = make(map[int32]int)
 	}
+	p.messagePriorities[msgType] = prio
+}
 
-	return 0
+// Calculates the priority of an inner packet type. Lower is more important.
+func (p *Parser) messagePriority(t int32) int {
+	if prio, ok := p.messagePriorities[t]; ok {
+		return prio
+	}
+	return defaultMessagePriorities[t]
 }
 
 // Provides a sortable structure for storing messages in the same packet.
-type pendingMessages []*pendingMessage
+type pendingMessages []pendingMessage
 
 func (ms pendingMessages) Len() int      { return len(ms) }
 func (ms pendingMessages) Swap(i, j int) { ms[i], ms[j] = ms[j], ms[i] }
@@ -53,7 +69,7 @@ func (ms pendingMessages) Less(i, j int) bool {
 	if ms[i].tick < ms[j].tick {
 		return true
 	}
-	return ms[i].priority() < ms[j].priority()
+	return ms[i].priority < ms[j].priority
 }
 
 // Internal parser for callback OnCDemoPacket, responsible for extracting
@@ -66,36 +82,64 @@ func (p *Parser) onCDemoPacket(m *dota.CDemoPacket) error {
 
 	// Read all messages from the buffer. Messages are packed serially as
 	// {type, size, data}. We keep reading until until less than a byte remains.
+	// Messages without callbacks are skipped, so they're never sorted or
+	// unmarshaled.
+	callbacks := p.dispatchCallbacks()
 	r := NewReader(m.GetData())
-	for r.remBytes() > 0 {
+	for r.remBytes() > 0 && r.err == nil {
 		t := int32(r.readUBitVar())
 		size := int(r.readVarUint32())
+		if !p.wantsPacket(callbacks, t) {
+			r.skipBytes(size)
+			continue
+		}
 		buf := r.readBytes(size)
-		ms = append(ms, &pendingMessage{p.Tick, t, buf})
+		ms = append(ms, pendingMessage{p.Tick, t, p.messagePriority(t), buf})
+	}
+	if r.err != nil {
+		return r.err
 	}
 
 	// Sort messages to ensure dependencies are met. For example, we need to
-	// process string tables before game events that may reference them.
-	sort.Sort(ms)
+	// process string tables before game events that may reference them. The
+	// sort is stable, so messages of equal priority keep their order.
+	if len(ms) > 1 {
+		sort.Stable(ms)
+	}
 
 	// Dispatch messages in order.
-	for _, m := range ms {
-		// Skip message we don't have a definition for (yet)
-		// XXX TODO: remove this when we get updated protos.
-		if m.t == 400 {
-			continue
-		}
+	for i := range ms {
+		m := &ms[i]
 
-		// Call each packet, panic if we encounter an error.
-		// XXX TODO: this should return the error up the chain. Panic for debugging.
-		if err := p.CallByPacketType(m.t, m.buf); err != nil {
-			panic(err)
+		// Call each packet, returning the first error encountered along with
+		// the packet type that caused it.
+		if err := p.callPendingMessage(m); err != nil {
+			return &DecodeError{Tick: m.tick, MessageType: m.t, Cause: err, inner: true}
 		}
 	}
 
 	return nil
 }
 
+// Determines whether or not an inner packet needs to be dispatched. Packets of
+// an unknown type are kept when they should cause an error.
+func (p *Parser) wantsPacket(callbacks *Callbacks, t int32) bool {
+	if callbacks.hasPacketCallbacks(t) {
+		return true
+	}
+	_, known := packetMessageTypes[t]
+	return !known && p.FailOnUnknownMessages
+}
+
+// Invokes callbacks for a single pending message.
+func (p *Parser) callPendingMessage(m *pendingMessage) (err error) {
+	defer p.recoverPanic(&err)
+	if p.Profiling {
+		defer p.profileSince(true, m.t, time.Now())
+	}
+	return p.CallByPacketType(m.t, m.buf)
+}
+
 // Internal parser for callback OnCDemoFullPacket.
 func (p *Parser) onCDemoFullPacket(m *dota.CDemoFullPacket) error {
 	// Per Valve docs, parse the CDemoStringTables first.
@@ -0,0 +1,97 @@
+package manta
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessagePriorityDefaults(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMinimal())
+	assert.NoError(err)
+
+	scenarios := []struct {
+		t        int32
+		expected int
+	}{
+		{int32(dota.NET_Messages_net_Tick), -10},
+		{int32(dota.SVC_Messages_svc_CreateStringTable), -10},
+		{int32(dota.SVC_Messages_svc_UpdateStringTable), -10},
+		{int32(dota.NET_Messages_net_SpawnGroup_Load), -10},
+		{int32(dota.NET_Messages_net_StringCmd), 0},
+		{int32(dota.EDotaUserMessages_DOTA_UM_ChatEvent), 0},
+		{int32(dota.SVC_Messages_svc_PacketEntities), 5},
+		{int32(dota.EBaseGameEvents_GE_Source1LegacyGameEvent), 10},
+	}
+
+	for _, s := range scenarios {
+		assert.Equal(s.expected, parser.messagePriority(s.t), packetNames[s.t])
+	}
+}
+
+func TestSetMessagePriority(t *testing.T) {
+	assert := assert.New(t)
+
+	replay := makeTestReplay(
+		testOuterMessage{dota.EDemoCommands_DEM_Packet, 1, &dota.CDemoPacket{
+			Data: makeTestInnerPackets(
+				testInnerPacket{int32(dota.EDotaUserMessages_DOTA_UM_ChatEvent), _proto_marshal(&dota.CDOTAUserMsg_ChatEvent{
+					Type: dota.DOTA_CHAT_MESSAGE_CHAT_MESSAGE_HERO_KILL.Enum(),
+				})},
+				testInnerPacket{int32(dota.NET_Messages_net_StringCmd), _proto_marshal(&dota.CNETMsg_StringCmd{
+					Command: proto.String("test"),
+				})},
+				testInnerPacket{int32(dota.NET_Messages_net_Tick), _proto_marshal(&dota.CNETMsg_Tick{
+					Tick: proto.Uint32(1),
+				})},
+				testInnerPacket{int32(dota.NET_Messages_net_NOP), _proto_marshal(&dota.CNETMsg_NOP{})},
+			),
+		}},
+	)
+
+	scenarios := []struct {
+		priorities map[int32]int
+		expected   []string
+	}{
+		// Ticks first, then the rest in the order received.
+		{
+			map[int32]int{},
+			[]string{"CNETMsg_Tick", "CDOTAUserMsg_ChatEvent", "CNETMsg_StringCmd", "CNETMsg_NOP"},
+		},
+		// Chat events last.
+		{
+			map[int32]int{int32(dota.EDotaUserMessages_DOTA_UM_ChatEvent): 20},
+			[]string{"CNETMsg_Tick", "CNETMsg_StringCmd", "CNETMsg_NOP", "CDOTAUserMsg_ChatEvent"},
+		},
+		// Overriding a default.
+		{
+			map[int32]int{int32(dota.NET_Messages_net_Tick): 0, int32(dota.NET_Messages_net_NOP): -1},
+			[]string{"CNETMsg_NOP", "CDOTAUserMsg_ChatEvent", "CNETMsg_StringCmd", "CNETMsg_Tick"},
+		},
+	}
+
+	for _, s := range scenarios {
+		parser, err := NewParser(replay)
+		assert.NoError(err)
+
+		for t, prio := range s.priorities {
+			parser.SetMessagePriority(t, prio)
+		}
+
+		order := make([]string, 0)
+		parser.OnMessage(func(tick uint32, msg proto.Message) error {
+			if _, ok := msg.(*dota.CDemoPacket); !ok {
+				order = append(order, fmt.Sprintf("%T", msg)[len("*dota."):])
+			}
+			return nil
+		})
+
+		assert.NoError(parser.Start())
+		assert.Equal(s.expected, order, "%v", s.priorities)
+	}
+}
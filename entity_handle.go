@@ -0,0 +1,53 @@
+package manta
+
+// Entity handles, as stored in properties such as m_hOwnerEntity, pack the
+// index of an entity into the low 14 bits and the low 10 bits of its serial
+// above that.
+const (
+	entityHandleIndexBits  = 14
+	entityHandleSerialBits = 10
+
+	// The handle of no entity.
+	invalidEntityHandle = 1<<(entityHandleIndexBits+entityHandleSerialBits) - 1
+)
+
+// Splits an entity handle into an entity index and serial.
+func splitEntityHandle(h uint32) (int32, int32) {
+	index := h & (1<<entityHandleIndexBits - 1)
+	serial := (h >> entityHandleIndexBits) & (1<<entityHandleSerialBits - 1)
+	return int32(index), int32(serial)
+}
+
+// Determines whether or not an entity serial matches the truncated serial in
+// a handle.
+func entityHandleMatches(h uint32, serial int32) bool {
+	_, handleSerial := splitEntityHandle(h)
+	return serial&(1<<entityHandleSerialBits-1) == handleSerial
+}
+
+// Looks up the entity referenced by a handle. Returns false if the handle is
+// invalid, or the entity at its index has since been replaced by another
+// with a different serial.
+func (p *Parser) EntityByHandle(h uint32) (*PacketEntity, bool) {
+	if h == invalidEntityHandle {
+		return nil, false
+	}
+
+	index, _ := splitEntityHandle(h)
+	pe, ok := p.PacketEntities[index]
+	if !ok || !entityHandleMatches(h, pe.Serial) {
+		return nil, false
+	}
+
+	return pe, true
+}
+
+// Fetches the entity referenced by a handle property, such as m_hOwnerEntity
+// on an item or m_hAssignedHero on a player.
+func (pe *PacketEntity) FetchEntity(key string) (*PacketEntity, bool) {
+	h, ok := pe.FetchUint32(key)
+	if !ok || pe.parser == nil {
+		return nil, false
+	}
+	return pe.parser.EntityByHandle(h)
+}
@@ -0,0 +1,50 @@
+package manta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntityByHandle(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMinimal())
+	assert.NoError(err)
+
+	hero := &PacketEntity{Index: 300, Serial: 1025, ClassBaseline: NewProperties(), Properties: NewProperties(), parser: parser}
+	item := &PacketEntity{Index: 5, Serial: 2, ClassBaseline: NewProperties(), Properties: NewProperties(), parser: parser}
+	parser.PacketEntities[hero.Index] = hero
+	parser.PacketEntities[item.Index] = item
+
+	// Serials are truncated to 10 bits in handles.
+	heroHandle := uint32(300 | 1<<14)
+	item.Properties.KV["m_hOwnerEntity"] = heroHandle
+	item.Properties.KV["m_hOldOwnerEntity"] = uint32(300 | 2<<14)
+	item.Properties.KV["m_hContainer"] = HANDLE_NONE
+
+	pe, ok := parser.EntityByHandle(heroHandle)
+	assert.True(ok)
+	assert.Equal(hero, pe)
+
+	scenarios := []struct {
+		key      string
+		expected *PacketEntity
+	}{
+		{"m_hOwnerEntity", hero},
+		{"m_hOldOwnerEntity", nil}, // serial doesn't match
+		{"m_hContainer", nil},      // invalid handle
+		{"m_hMissing", nil},        // no such property
+	}
+
+	for _, s := range scenarios {
+		pe, ok := item.FetchEntity(s.key)
+		assert.Equal(s.expected != nil, ok, s.key)
+		assert.Equal(s.expected, pe, s.key)
+	}
+
+	// Handles to deleted entities don't resolve.
+	delete(parser.PacketEntities, hero.Index)
+	_, ok = parser.EntityByHandle(heroHandle)
+	assert.False(ok)
+}
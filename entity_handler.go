@@ -0,0 +1,129 @@
+package manta
+
+import (
+	"regexp"
+	"strings"
+)
+
+// A set of entity event types, used to filter the events offered to handlers.
+type EntityEventMask uint
+
+// Matches every entity event type.
+const EntityEventMask_All = ^EntityEventMask(0)
+
+// Create a new mask matching the given entity event types.
+func EntityEvents(types ...EntityEventType) EntityEventMask {
+	mask := EntityEventMask(0)
+	for _, t := range types {
+		mask |= 1 << uint(t)
+	}
+	return mask
+}
+
+// Determines whether or not the mask matches an entity event type.
+func (m EntityEventMask) Has(t EntityEventType) bool {
+	return m&(1<<uint(t)) != 0
+}
+
+// An entity handler, along with the classes and events it's for.
+type entityListener struct {
+	match  func(className string) bool
+	events EntityEventMask
+	fn     packetEntityHandler
+}
+
+// Registers a handler for events on entities with a class name matching
+// classPattern, filtered by the given event mask. Patterns are matched exactly,
+// by prefix when they end with "*", or as a regular expression when wrapped in
+// slashes. For example:
+//
+//	p.OnEntity("CDOTA_Unit_Hero_*", manta.EntityEvents(manta.EntityEventType_Create), fn)
+//	p.OnEntity("/^CDOTA_Item_(Rapier|Gem)$/", manta.EntityEventMask_All, fn)
+//
+// Handlers are called after those registered with OnPacketEntity. Returns an
+// error, without registering the handler, if a regular expression is
+// malformed.
+func (p *Parser) OnEntity(classPattern string, events EntityEventMask, fn packetEntityHandler) error {
+	match, err := compileClassPattern(classPattern)
+	if err != nil {
+		return err
+	}
+
+	p.entityListeners = append(p.entityListeners, &entityListener{
+		match:  match,
+		events: events,
+		fn:     fn,
+	})
+	p.buildEntityClassTable()
+
+	return nil
+}
+
+// Compiles a class pattern for OnEntity into a matching function.
+func compileClassPattern(pattern string) (func(string) bool, error) {
+	switch {
+	case len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/"):
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, _errorf("invalid class pattern %q: %w", pattern, err)
+		}
+		return re.MatchString, nil
+
+	case strings.HasSuffix(pattern, "*"):
+		prefix := strings.TrimSuffix(pattern, "*")
+		return func(className string) bool {
+			return strings.HasPrefix(className, prefix)
+		}, nil
+	}
+
+	return func(className string) bool {
+		return className == pattern
+	}, nil
+}
+
+// Rebuilds the table of entity handlers by class id, for the known classes.
+func (p *Parser) buildEntityClassTable() {
+	p.entityClassTable = make(map[int32][]*entityListener, len(p.ClassInfo))
+	if len(p.entityListeners) == 0 {
+		return
+	}
+
+	for classId := range p.ClassInfo {
+		p.entityListenersFor(classId)
+	}
+}
+
+// Returns the entity handlers for a class id, filling in the table if needed.
+func (p *Parser) entityListenersFor(classId int32) []*entityListener {
+	if len(p.entityListeners) == 0 {
+		return nil
+	}
+
+	if listeners, ok := p.entityClassTable[classId]; ok {
+		return listeners
+	}
+
+	className := p.ClassInfo[classId]
+	listeners := make([]*entityListener, 0)
+	for _, l := range p.entityListeners {
+		if l.match(className) {
+			listeners = append(listeners, l)
+		}
+	}
+	p.entityClassTable[classId] = listeners
+
+	return listeners
+}
+
+// Offers an entity event to the handlers registered for its class.
+func (p *Parser) callEntityListeners(pe *PacketEntity, t EntityEventType) error {
+	for _, l := range p.entityListenersFor(pe.ClassId) {
+		if !l.events.Has(t) {
+			continue
+		}
+		if err := l.fn(pe, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
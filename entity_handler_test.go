@@ -0,0 +1,90 @@
+package manta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntityEventMask(t *testing.T) {
+	assert := assert.New(t)
+
+	mask := EntityEvents(EntityEventType_Create, EntityEventType_Enter)
+	assert.True(mask.Has(EntityEventType_Create))
+	assert.True(mask.Has(EntityEventType_Enter))
+	assert.False(mask.Has(EntityEventType_Update))
+	assert.False(mask.Has(EntityEventType_Leave))
+	assert.True(EntityEventMask_All.Has(EntityEventType_Leave))
+	assert.False(EntityEvents().Has(EntityEventType_Create))
+}
+
+func TestOnEntity(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+	for classId, className := range map[int32]string{
+		2: "CDOTA_Unit_Hero_Axe",
+		3: "CDOTA_Unit_Hero_Lina",
+		4: "CDOTA_Item_Rapier",
+	} {
+		parser.ClassInfo[classId] = className
+		parser.ClassBaselines[classId] = NewProperties()
+		parser.serializers[className] = parser.serializers["CTest"]
+	}
+
+	events := make(map[string][]string)
+	record := func(name string) packetEntityHandler {
+		return func(pe *PacketEntity, t EntityEventType) error {
+			events[name] = append(events[name], _sprintf("%s %d", pe.ClassName, t))
+			return nil
+		}
+	}
+
+	assert.NoError(parser.OnEntity("CDOTA_Item_Rapier", EntityEventMask_All, record("exact")))
+	assert.NoError(parser.OnEntity("CDOTA_Unit_Hero_*", EntityEvents(EntityEventType_Create, EntityEventType_Delete), record("prefix")))
+	assert.NoError(parser.OnEntity("/_(Axe|Rapier)$/", EntityEvents(EntityEventType_Update), record("regexp")))
+
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(
+		testEntityEntry{1, EntityEventType_Create, 1, 1, nil},
+		testEntityEntry{2, EntityEventType_Create, 2, 1, nil},
+		testEntityEntry{3, EntityEventType_Create, 3, 1, nil},
+		testEntityEntry{4, EntityEventType_Create, 4, 1, nil},
+	)))
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{1, EntityEventType_Update, 0, 0, nil},
+		testEntityEntry{2, EntityEventType_Update, 0, 0, nil},
+		testEntityEntry{3, EntityEventType_Update, 0, 0, nil},
+		testEntityEntry{4, EntityEventType_Update, 0, 0, nil},
+	)))
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{2, EntityEventType_Delete, 0, 0, nil},
+		testEntityEntry{4, EntityEventType_Leave, 0, 0, nil},
+	)))
+
+	assert.Equal(map[string][]string{
+		"exact": {"CDOTA_Item_Rapier 1", "CDOTA_Item_Rapier 2", "CDOTA_Item_Rapier 4"},
+		"prefix": {
+			"CDOTA_Unit_Hero_Axe 1", "CDOTA_Unit_Hero_Lina 1",
+			"CDOTA_Unit_Hero_Axe 3",
+		},
+		"regexp": {"CDOTA_Unit_Hero_Axe 2", "CDOTA_Item_Rapier 2"},
+	}, events)
+
+	// Handlers registered later apply to classes already seen.
+	assert.NoError(parser.OnEntity("CTest", EntityEventMask_All, record("late")))
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{1, EntityEventType_Update, 0, 0, nil},
+	)))
+	assert.Equal([]string{"CTest 2"}, events["late"])
+}
+
+func TestOnEntityBadPattern(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+	err := parser.OnEntity("/CDOTA_(/", EntityEventMask_All, func(pe *PacketEntity, t EntityEventType) error {
+		return nil
+	})
+	assert.Error(err)
+	assert.Len(parser.entityListeners, 0)
+}
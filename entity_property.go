@@ -0,0 +1,176 @@
+package manta
+
+import (
+	"path"
+	"reflect"
+	"sort"
+)
+
+// A function that handles a change to an entity property.
+type entityPropertyHandler func(pe *PacketEntity, key string, old, new interface{}) error
+
+// A property change handler, along with the entities and properties it's for.
+type entityPropertyListener struct {
+	matchClass  func(className string) bool
+	propPattern string
+	fn          entityPropertyHandler
+}
+
+// A change to an entity property waiting to be offered to handlers.
+type entityPropertyChange struct {
+	pe  *PacketEntity
+	key string
+	old interface{}
+	new interface{}
+}
+
+// Registers a handler for changes to properties matching propPattern, on
+// entities with a class name matching className. The class name is a pattern
+// as used by OnEntity, so "CDOTA_Unit_Hero_*" matches all heroes and "*"
+// matches anything. The property is a pattern as used by path.Match. The
+// state of an entity when it's created, including properties from its
+// baselines, is reported with old values of nil, and handlers are called after
+// those registered with OnPacketEntity. Returns an error, without registering
+// the handler, if either pattern is malformed.
+func (p *Parser) OnEntityPropertyChange(className, propPattern string, fn func(pe *PacketEntity, key string, old, new interface{}) error) error {
+	matchClass, err := compileClassPattern(className)
+	if err != nil {
+		return err
+	}
+
+	if _, err := path.Match(propPattern, ""); err != nil {
+		return _errorf("invalid property pattern %q: %w", propPattern, err)
+	}
+
+	p.entityPropertyListeners = append(p.entityPropertyListeners, &entityPropertyListener{matchClass, propPattern, fn})
+	p.entityPropertyClasses = nil
+
+	return nil
+}
+
+// Returns the property change listeners for a class, caching the result.
+func (p *Parser) entityPropertyListenersFor(className string) []*entityPropertyListener {
+	if len(p.entityPropertyListeners) == 0 {
+		return nil
+	}
+
+	if listeners, ok := p.entityPropertyClasses[className]; ok {
+		return listeners
+	}
+
+	if p.entityPropertyClasses == nil {
+		p.entityPropertyClasses = make(map[string][]*entityPropertyListener)
+	}
+
+	listeners := make([]*entityPropertyListener, 0)
+	for _, l := range p.entityPropertyListeners {
+		if l.matchClass(className) {
+			listeners = append(listeners, l)
+		}
+	}
+	p.entityPropertyClasses[className] = listeners
+
+	return listeners
+}
+
+// Records the changes decoded properties are about to make to an entity, for
+// properties that somebody is listening to.
+func (p *Parser) recordPropertyChanges(changes []*entityPropertyChange, pe *PacketEntity, props *Properties) []*entityPropertyChange {
+	listeners := p.entityPropertyListenersFor(pe.ClassName)
+	if len(listeners) == 0 {
+		return changes
+	}
+
+	for _, k := range sortedPropertyKeys(props) {
+		old, _ := pe.Fetch(k)
+		new := props.KV[k]
+		if !reflect.DeepEqual(old, new) && matchesPropertyListener(listeners, k) {
+			changes = append(changes, &entityPropertyChange{pe, k, old, new})
+		}
+	}
+
+	return changes
+}
+
+// Records the state of a newly created entity as changes from nil, for
+// properties that somebody is listening to. The state includes properties
+// from the class baseline and entity baseline as well as those decoded.
+func (p *Parser) recordCreateChanges(changes []*entityPropertyChange, pe *PacketEntity) []*entityPropertyChange {
+	listeners := p.entityPropertyListenersFor(pe.ClassName)
+	if len(listeners) == 0 {
+		return changes
+	}
+
+	for _, k := range sortedPropertyKeys(pe.ClassBaseline, pe.Properties) {
+		if matchesPropertyListener(listeners, k) {
+			new, _ := pe.Fetch(k)
+			changes = append(changes, &entityPropertyChange{pe, k, nil, new})
+		}
+	}
+
+	return changes
+}
+
+// Records the changes made by replacing the state of an entity with that from
+// a full update, for properties that somebody is listening to.
+func (p *Parser) recordStateChanges(changes []*entityPropertyChange, pe, full *PacketEntity) []*entityPropertyChange {
+	listeners := p.entityPropertyListenersFor(pe.ClassName)
+	if len(listeners) == 0 {
+		return changes
+	}
+
+	for _, k := range sortedPropertyKeys(pe.ClassBaseline, pe.Properties, full.ClassBaseline, full.Properties) {
+		old, _ := pe.Fetch(k)
+		new, _ := full.Fetch(k)
+		if !reflect.DeepEqual(old, new) && matchesPropertyListener(listeners, k) {
+			changes = append(changes, &entityPropertyChange{pe, k, old, new})
+		}
+	}
+
+	return changes
+}
+
+// Offers recorded property changes to matching handlers.
+func (p *Parser) callPropertyChanges(changes []*entityPropertyChange) error {
+	for _, c := range changes {
+		for _, l := range p.entityPropertyListenersFor(c.pe.ClassName) {
+			if ok, _ := path.Match(l.propPattern, c.key); !ok {
+				continue
+			}
+			if err := l.fn(c.pe, c.key, c.old, c.new); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Determines whether or not any of the listeners is for a property.
+func matchesPropertyListener(listeners []*entityPropertyListener, key string) bool {
+	for _, l := range listeners {
+		if ok, _ := path.Match(l.propPattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the keys found in any of the given sets of properties, in order.
+func sortedPropertyKeys(sets ...*Properties) []string {
+	seen := make(map[string]bool)
+	keys := make([]string, 0)
+	for _, props := range sets {
+		if props == nil {
+			continue
+		}
+		for k := range props.KV {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,105 @@
+package manta
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnEntityPropertyChange(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+	parser.ClassInfo[2] = "CTestItem"
+	parser.ClassBaselines[2] = NewProperties()
+	parser.serializers["CTestItem"] = parser.serializers["CTest"]
+
+	levels := make([]string, 0)
+	assert.NoError(parser.OnEntityPropertyChange("CTest", "m_iCurrent*", func(pe *PacketEntity, key string, old, new interface{}) error {
+		levels = append(levels, _sprintf("%d %s %v %v", pe.Index, key, old, new))
+		return nil
+	}))
+
+	health := make([]string, 0)
+	assert.NoError(parser.OnEntityPropertyChange("*", "m_iHealth", func(pe *PacketEntity, key string, old, new interface{}) error {
+		health = append(health, _sprintf("%d %s %v %v", pe.Index, key, old, new))
+		return nil
+	}))
+
+	// Properties set on creation are changes from nil.
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(
+		testEntityEntry{1, EntityEventType_Create, 1, 7, map[int]uint32{0: 1, 2: 100}},
+		testEntityEntry{2, EntityEventType_Create, 2, 8, map[int]uint32{1: 3}},
+	)))
+	assert.Equal([]string{"1 m_iCurrentLevel <nil> 1"}, levels)
+	assert.Equal([]string{"1 m_iHealth <nil> 100"}, health)
+
+	// Only properties whose values change are reported.
+	levels, health = levels[:0], health[:0]
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{1, EntityEventType_Update, 0, 0, map[int]uint32{0: 2, 2: 100}},
+		testEntityEntry{2, EntityEventType_Update, 0, 0, map[int]uint32{1: 2}},
+	)))
+	assert.Equal([]string{"1 m_iCurrentLevel 1 2"}, levels)
+	assert.Empty(health)
+
+	// Changes made when resyncing with a full update are reported too.
+	levels, health = levels[:0], health[:0]
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(
+		testEntityEntry{1, EntityEventType_Create, 1, 7, map[int]uint32{0: 3, 2: 90}},
+		testEntityEntry{2, EntityEventType_Create, 2, 8, map[int]uint32{1: 2}},
+	)))
+	assert.Equal([]string{"1 m_iCurrentLevel 2 3"}, levels)
+	assert.Equal([]string{"1 m_iHealth 100 90"}, health)
+}
+
+func TestOnEntityPropertyChangeBaselines(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+	parser.ClassBaselines[1].KV["m_iCurrentCharges"] = uint32(5)
+
+	changes := make([]string, 0)
+	assert.NoError(parser.OnEntityPropertyChange("CTest", "*", func(pe *PacketEntity, key string, old, new interface{}) error {
+		changes = append(changes, _sprintf("%d %s %v %v", pe.Index, key, old, new))
+		return nil
+	}))
+
+	// Properties from the class baseline are reported on creation. Save the
+	// entity to baseline slot 1.
+	m := makeTestPacketEntitiesFull(testEntityEntry{1, EntityEventType_Create, 1, 7, map[int]uint32{2: 100}})
+	m.UpdateBaseline = proto.Bool(true)
+	assert.NoError(parser.onCSVCMsg_PacketEntities(m))
+	assert.Equal([]string{"1 m_iCurrentCharges <nil> 5", "1 m_iHealth <nil> 100"}, changes)
+
+	// So are properties from the entity baseline.
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{1, EntityEventType_Delete, 0, 0, nil},
+	)))
+	changes = changes[:0]
+	m = makeTestPacketEntitiesMessage(testEntityEntry{1, EntityEventType_Create, 1, 8, map[int]uint32{0: 4}})
+	m.Baseline = proto.Int32(1)
+	assert.NoError(parser.onCSVCMsg_PacketEntities(m))
+	assert.Equal([]string{"1 m_iCurrentCharges <nil> 5", "1 m_iCurrentLevel <nil> 4", "1 m_iHealth <nil> 100"}, changes)
+
+	// Entities created when resyncing with a full update start from the
+	// class baseline alone.
+	changes = changes[:0]
+	m = makeTestPacketEntitiesFull(testEntityEntry{1, EntityEventType_Create, 1, 9, map[int]uint32{0: 6}})
+	m.Baseline = proto.Int32(1)
+	assert.NoError(parser.onCSVCMsg_PacketEntities(m))
+	assert.Equal([]string{"1 m_iCurrentCharges <nil> 5", "1 m_iCurrentLevel <nil> 6"}, changes)
+}
+
+func TestOnEntityPropertyChangeBadPattern(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+	fn := func(pe *PacketEntity, key string, old, new interface{}) error {
+		return nil
+	}
+	assert.Error(parser.OnEntityPropertyChange("/CTest(/", "m_iHealth", fn))
+	assert.Error(parser.OnEntityPropertyChange("CTest", "m_iHealth[", fn))
+	assert.Len(parser.entityPropertyListeners, 0)
+}
@@ -0,0 +1,88 @@
+package manta
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dotabuff/manta/dota"
+)
+
+var (
+	// Returned when the input doesn't start with the Source 2 replay magic.
+	ErrUnexpectedMagic = errors.New("unexpected magic")
+
+	// Returned when the input is a Source 1 replay, which this parser doesn't
+	// support. Wraps ErrUnexpectedMagic.
+	ErrSource1Replay = fmt.Errorf("%w: source 1 replay", ErrUnexpectedMagic)
+
+	// Returned when the replay ends partway through a message, such as when
+	// a download or upload was cut short.
+	ErrTruncatedReplay = errors.New("truncated replay")
+
+	// Returned when the replay contains a game event, or a message type when
+	// Parser.FailOnUnknownMessages is set, that this version of the parser
+	// doesn't know about. These are usually resolved by updating the parser.
+	ErrUnknownMessage = errors.New("unknown message")
+
+	// Returned when seeking within a replay that isn't read from an
+	// uncompressed io.ReadSeeker, such as a file or byte slice.
+	ErrNotSeekable = errors.New("replay is not seekable")
+
+	// Returned when Parser.CheckEntityConsistency is set and the entity state
+	// built up from deltas differs from a full update.
+	ErrEntityStateMismatch = errors.New("entity state mismatch")
+)
+
+// A DecodeError describes a failure while processing a message from the
+// replay. It records where in the replay the failure happened, and wraps the
+// underlying cause, which may also be an error returned from a callback.
+type DecodeError struct {
+	// The parser tick at which the failure happened.
+	Tick uint32
+
+	// The type of the message being processed. This is an inner packet type
+	// (see CallByPacketType) when the failure happened inside a CDemoPacket,
+	// otherwise an outer demo command type (see CallByDemoType). It is
+	// EDemoCommands_DEM_Error when the outer message couldn't be read.
+	MessageType int32
+
+	// The byte offset of the outer message within the replay stream.
+	Offset int64
+
+	// The underlying error.
+	Cause error
+
+	inner bool
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("tick %d: offset %d: %s: %s", e.Tick, e.Offset, e.messageName(), e.Cause)
+}
+
+// Returns the underlying error, allowing use of errors.Is and errors.As.
+func (e *DecodeError) Unwrap() error {
+	return e.Cause
+}
+
+// Returns a readable name for the message type.
+func (e *DecodeError) messageName() string {
+	if e.inner {
+		if name, ok := packetNames[e.MessageType]; ok {
+			return name
+		}
+		return _sprintf("packet type %d", e.MessageType)
+	}
+
+	if name, ok := dota.EDemoCommands_name[e.MessageType]; ok {
+		return name
+	}
+	return _sprintf("demo type %d", e.MessageType)
+}
+
+// Converts a value recovered from a panic into an error.
+func panicError(r interface{}) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return _errorf("panic: %v", r)
+}
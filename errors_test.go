@@ -0,0 +1,169 @@
+package manta
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeErrorFromCallback(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMinimal())
+	assert.NoError(err)
+
+	errTest := errors.New("test error")
+	parser.Callbacks.OnCDemoFileInfo(func(m *dota.CDemoFileInfo) error {
+		return errTest
+	})
+
+	err = parser.Start()
+	assert.True(errors.Is(err, errTest))
+
+	var decodeErr *DecodeError
+	if assert.True(errors.As(err, &decodeErr)) {
+		assert.Equal(uint32(100), decodeErr.Tick)
+		assert.Equal(int32(dota.EDemoCommands_DEM_FileInfo), decodeErr.MessageType)
+		assert.True(decodeErr.Offset > 16)
+	}
+}
+
+func TestDecodeErrorFromPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMinimal())
+	assert.NoError(err)
+
+	parser.Callbacks.OnCDemoFileHeader(func(m *dota.CDemoFileHeader) error {
+		panic("test panic")
+	})
+
+	var decodeErr *DecodeError
+	err = parser.Start()
+	if assert.True(errors.As(err, &decodeErr)) {
+		assert.Equal(int32(dota.EDemoCommands_DEM_FileHeader), decodeErr.MessageType)
+		assert.Equal(int64(16), decodeErr.Offset)
+	}
+
+	// With recovery disabled the panic propagates.
+	parser, err = NewParser(makeTestReplayMinimal())
+	assert.NoError(err)
+	parser.RecoverPanics = false
+	parser.Callbacks.OnCDemoFileHeader(func(m *dota.CDemoFileHeader) error {
+		panic("test panic")
+	})
+	assert.Panics(func() { parser.Start() })
+}
+
+func TestErrUnexpectedMagic(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := makeTestReplayMinimal()
+	copy(buf, magicSource1)
+
+	_, err := NewParser(buf)
+	assert.True(errors.Is(err, ErrUnexpectedMagic))
+}
+
+func TestErrTruncatedReplay(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := NewParser(magicSource2[:4])
+	assert.True(errors.Is(err, ErrTruncatedReplay))
+}
+
+func TestErrUnknownMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplay(
+		testOuterMessage{dota.EDemoCommands(30), 1, &dota.CDemoStop{}},
+	))
+	assert.NoError(err)
+
+	parser.FailOnUnknownMessages = true
+	err = parser.Start()
+	assert.True(errors.Is(err, ErrUnknownMessage))
+
+	var decodeErr *DecodeError
+	if assert.True(errors.As(err, &decodeErr)) {
+		assert.Equal(int32(30), decodeErr.MessageType)
+	}
+}
+
+// Writes bits least significant first, as read by Reader.
+type testBitWriter struct {
+	buf []byte
+	n   uint
+}
+
+func (w *testBitWriter) write(v uint64, bits uint) {
+	for i := uint(0); i < bits; i++ {
+		if w.n%8 == 0 {
+			w.buf = append(w.buf, 0)
+		}
+		w.buf[w.n/8] |= byte((v>>i)&1) << (w.n % 8)
+		w.n++
+	}
+}
+
+// An inner packet of a CDemoPacket.
+type testInnerPacket struct {
+	t    int32
+	data []byte
+}
+
+// Packs inner packets for a CDemoPacket, in order. Types must be below 4096
+// and data shorter than 128 bytes.
+func makeTestInnerPackets(packets ...testInnerPacket) []byte {
+	w := &testBitWriter{}
+	for _, p := range packets {
+		if p.t < 16 {
+			w.write(uint64(p.t), 6)
+		} else {
+			w.write(uint64(p.t&15|32), 6)
+			w.write(uint64(p.t>>4), 8)
+		}
+		w.write(uint64(len(p.data)), 8)
+		for _, b := range p.data {
+			w.write(uint64(b), 8)
+		}
+	}
+	return w.buf
+}
+
+func TestUnknownMessageCallbacks(t *testing.T) {
+	assert := assert.New(t)
+
+	// Type 30 isn't a demo command and type 2 isn't a packet type.
+	parser, err := NewParser(makeTestReplay(
+		testOuterMessage{dota.EDemoCommands(30), 1, &dota.CDemoStop{}},
+		testOuterMessage{dota.EDemoCommands_DEM_Packet, 2, &dota.CDemoPacket{
+			Data: makeTestInnerPackets(testInnerPacket{2, []byte("raw")}),
+		}},
+		testOuterMessage{dota.EDemoCommands_DEM_FileInfo, 3, &dota.CDemoFileInfo{}},
+	))
+	assert.NoError(err)
+
+	unknown := make([]string, 0)
+	parser.Callbacks.OnUnknownDemo(func(tick uint32, t int32, raw []byte) error {
+		unknown = append(unknown, _sprintf("demo %d %d", tick, t))
+		return nil
+	})
+	parser.Callbacks.OnUnknownPacket(func(tick uint32, t int32, raw []byte) error {
+		unknown = append(unknown, _sprintf("packet %d %d %s", tick, t, raw))
+		return nil
+	})
+
+	fileInfo := false
+	parser.Callbacks.OnCDemoFileInfo(func(m *dota.CDemoFileInfo) error {
+		fileInfo = true
+		return nil
+	})
+
+	// Unknown messages are skipped, so parsing carries on.
+	assert.NoError(parser.Start())
+	assert.True(fileInfo)
+	assert.Equal([]string{"demo 1 30", "packet 2 2 raw"}, unknown)
+}
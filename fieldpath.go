@@ -88,12 +88,17 @@ func newFieldpath(parentTbl *dt, huf *HuffmanTree) *fieldpath {
 }
 
 // Walk an encoded fieldpath based on a huffman tree
-func (fp *fieldpath) walk(r *Reader) {
+func (fp *fieldpath) walk(r *Reader) error {
 	cnt := 0
 	root := fp.tree
 	node := root
 
 	for fp.finished == false {
+		// Stop walking if the buffer has run out.
+		if r.err != nil {
+			return r.err
+		}
+
 		cnt++
 		if r.readBits(1) == 1 {
 			if i := (*node).Right(); i.IsLeaf() {
@@ -101,7 +106,9 @@ func (fp *fieldpath) walk(r *Reader) {
 				fieldpathLookup[i.Value()].Function(r, fp)
 
 				if fp.finished == false {
-					fp.addField()
+					if err := fp.addField(); err != nil {
+						return err
+					}
 					_debugfl(6, "Reached in %d bits, %s, %d", cnt, fp.fields[len(fp.fields)-1].Name, r.pos)
 				}
 
@@ -115,7 +122,9 @@ func (fp *fieldpath) walk(r *Reader) {
 				fieldpathLookup[i.Value()].Function(r, fp)
 
 				if fp.finished == false {
-					fp.addField()
+					if err := fp.addField(); err != nil {
+						return err
+					}
 					_debugfl(6, "Reached in %d bits, %s, %d", cnt, fp.fields[len(fp.fields)-1].Name, r.pos)
 				}
 
@@ -125,16 +134,18 @@ func (fp *fieldpath) walk(r *Reader) {
 			}
 		}
 	}
+
+	return nil
 }
 
 // Adds a field based on the current index
-func (fp *fieldpath) addField() {
+func (fp *fieldpath) addField() error {
 	cDt := fp.parent
 
 	var name string
 	var i int
 
-	if debugLevel >= 6 {
+	if getDebugLevel() >= 6 {
 		var path string
 		for i := 0; i < len(fp.index)-1; i++ {
 			path += strconv.Itoa(int(fp.index[i])) + "/"
@@ -144,20 +155,29 @@ func (fp *fieldpath) addField() {
 	}
 
 	for i = 0; i < len(fp.index)-1; i++ {
+		if int(fp.index[i]) >= len(cDt.Properties) || fp.index[i] < 0 {
+			return _errorf("field index %d out of range in %s", fp.index[i], cDt.Name)
+		}
+
 		if cDt.Properties[fp.index[i]].Table != nil {
 			cDt = cDt.Properties[fp.index[i]].Table
 			name += cDt.Name + "."
 		} else {
 
 			// Hint:
-			// If this panics, the property in question migh have a type that doesn't premit automatic array deduction (e.g. no CUtlVector prefix, or [] suffix).
+			// If this fails, the property in question migh have a type that doesn't premit automatic array deduction (e.g. no CUtlVector prefix, or [] suffix).
 			// Adjust the type manualy in property_serializers.go
 
-			_panicf("expected table in fp properties: %v, %v", cDt.Properties[fp.index[i]].Field.Name, cDt.Properties[fp.index[i]].Field.Type)
+			return _errorf("expected table in fp properties: %v, %v", cDt.Properties[fp.index[i]].Field.Name, cDt.Properties[fp.index[i]].Field.Type)
 		}
 	}
 
+	if int(fp.index[i]) >= len(cDt.Properties) || fp.index[i] < 0 {
+		return _errorf("field index %d out of range in %s", fp.index[i], cDt.Name)
+	}
+
 	fp.fields = append(fp.fields, &fieldpath_field{name + cDt.Properties[fp.index[i]].Field.Name, cDt.Properties[fp.index[i]].Field})
+	return nil
 }
 
 // Returns a huffman tree based on the operation weights
@@ -82,7 +82,8 @@ func TestFieldpath(t *testing.T) {
 
 	// Retrieve the flattened field serializer
 	p := &Parser{}
-	fs := p.ParseSendTables(m, GetDefaultPropertySerializerTable())
+	fs, err := p.ParseSendTables(m, GetDefaultPropertySerializerTable())
+	assert.NoError(err)
 
 	// Build the huffman tree
 	huf := newFieldpathHuffman()
@@ -112,7 +113,7 @@ func TestFieldpath(t *testing.T) {
 
 		// Initialize a field path and walk it
 		fieldPath := newFieldpath(serializer, &huf)
-		fieldPath.walk(NewReader(buf))
+		assert.NoError(fieldPath.walk(NewReader(buf)))
 
 		// Verify field count
 		assert.Equal(len(fieldPath.fields), s.expectCount)
@@ -2,6 +2,7 @@ package manta
 
 import (
 	"encoding/json"
+	"sync"
 
 	"github.com/dotabuff/manta/dota"
 	"github.com/golang/protobuf/proto"
@@ -29,6 +30,19 @@ type dt_field struct {
 	Serializer *PropertySerializer `json:"-"`
 
 	build uint32
+
+	// Decoder for quantized floats, created on first use. Guarded by a
+	// sync.Once as fields may be shared by parsers in several goroutines.
+	qfd     *QuantizedFloatDecoder
+	qfdOnce sync.Once
+}
+
+// Returns the quantized float decoder for the field, creating it if needed.
+func (f *dt_field) quantizedFloatDecoder() *QuantizedFloatDecoder {
+	f.qfdOnce.Do(func() {
+		f.qfd = InitQFD(f)
+	})
+	return f.qfd
 }
 
 // A single datatable
@@ -37,6 +51,32 @@ type dt struct {
 	Flags      *int32
 	Version    int32
 	Properties []*dt_property
+
+	// Positions of property names in serializer order, created on first use.
+	keyOrder     map[string]int
+	keyOrderOnce sync.Once
+}
+
+// Returns the positions of the names of all properties in the table,
+// including nested tables, in serializer order.
+func (t *dt) propertyOrder() map[string]int {
+	t.keyOrderOnce.Do(func() {
+		t.keyOrder = make(map[string]int)
+
+		var walk func(tbl *dt, prefix string)
+		walk = func(tbl *dt, prefix string) {
+			for _, p := range tbl.Properties {
+				if p.Field != nil {
+					t.keyOrder[prefix+p.Field.Name] = len(t.keyOrder)
+				}
+				if p.Table != nil {
+					walk(p.Table, prefix+p.Table.Name+".")
+				}
+			}
+		}
+		walk(t, "")
+	})
+	return t.keyOrder
 }
 
 // The flattened serializers object
@@ -65,7 +105,7 @@ func (sers *flattened_serializers) dump_json(name string) string {
 }
 
 // Fills properties for a data table
-func (sers *flattened_serializers) recurse_table(cur *dota.ProtoFlattenedSerializerT) *dt {
+func (sers *flattened_serializers) recurse_table(cur *dota.ProtoFlattenedSerializerT) (*dt, error) {
 	// Basic table structure
 	table := &dt{
 		Name:       sers.proto.GetSymbols()[cur.GetSerializerNameSym()],
@@ -170,7 +210,7 @@ func (sers *flattened_serializers) recurse_table(cur *dota.ProtoFlattenedSeriali
 			pSerializer := sers.Serializers[pFieldName][pFieldVersion]
 
 			if pSerializer == nil {
-				_panicf("Error: Serializer version %d for %s hasn't been added yet.", pFieldVersion, pFieldName)
+				return nil, _errorf("Error: Serializer version %d for %s hasn't been added yet.", pFieldVersion, pFieldName)
 			}
 
 			prop.Table = pSerializer
@@ -206,9 +246,12 @@ func (sers *flattened_serializers) recurse_table(cur *dota.ProtoFlattenedSeriali
 
 				// Copy parent prop to rename it's name according to the array index
 				if prop.Table != nil {
-					nTable := *prop.Table
-					nTable.Name = _sprintf("%04d", i)
-					tmpDt.Properties[len(tmpDt.Properties)-1].Table = &nTable
+					tmpDt.Properties[len(tmpDt.Properties)-1].Table = &dt{
+						Name:       _sprintf("%04d", i),
+						Flags:      prop.Table.Flags,
+						Version:    prop.Table.Version,
+						Properties: prop.Table.Properties,
+					}
 				}
 			}
 
@@ -221,25 +264,28 @@ func (sers *flattened_serializers) recurse_table(cur *dota.ProtoFlattenedSeriali
 		)
 	}
 
-	return table
+	return table, nil
 }
 
 // Parses a CDemoSendTables packet
-func (p *Parser) ParseSendTables(m *dota.CDemoSendTables, pst *PropertySerializerTable) *flattened_serializers {
+func (p *Parser) ParseSendTables(m *dota.CDemoSendTables, pst *PropertySerializerTable) (*flattened_serializers, error) {
 	// This packet just contains a single large buffer
 	r := NewReader(m.GetData())
 
 	// The buffer starts with a varint encoded length
 	size := int(r.readVarUint32())
 	if size != r.remBytes() {
-		_panicf("expected %d additional bytes, got %d", size, r.remBytes())
+		return nil, _errorf("expected %d additional bytes, got %d", size, r.remBytes())
 	}
 
 	// Read the rest of the buffer as a CSVCMsg_FlattenedSerializer.
 	buf := r.readBytes(size)
+	if r.err != nil {
+		return nil, r.err
+	}
 	msg := &dota.CSVCMsg_FlattenedSerializer{}
 	if err := proto.Unmarshal(buf, msg); err != nil {
-		_panicf("cannot decode proto: %s", err)
+		return nil, _errorf("cannot decode proto: %w", err)
 	}
 
 	// Create the flattened_serializers object and fill it
@@ -259,14 +305,23 @@ func (p *Parser) ParseSendTables(m *dota.CDemoSendTables, pst *PropertySerialize
 			fs.Serializers[sName] = make(map[int32]*dt)
 		}
 
-		fs.Serializers[sName][sVer] = fs.recurse_table(o)
+		table, err := fs.recurse_table(o)
+		if err != nil {
+			return nil, err
+		}
+		fs.Serializers[sName][sVer] = table
 	}
 
-	return fs
+	return fs, nil
 }
 
 // Internal callback for OnCDemoSendTables.
 func (p *Parser) onCDemoSendTables(m *dota.CDemoSendTables) error {
-	p.serializers = p.ParseSendTables(m, GetDefaultPropertySerializerTable()).Serializers
+	fs, err := p.ParseSendTables(m, GetDefaultPropertySerializerTable())
+	if err != nil {
+		return err
+	}
+
+	p.serializers = fs.Serializers
 	return nil
 }
@@ -0,0 +1,89 @@
+package manta
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/dotabuff/manta/dota"
+)
+
+// Represents the format of replay data.
+type Format int
+
+// Possible replay data formats
+const (
+	Format_Unknown   = Format(0)
+	Format_Source1   = Format(1)
+	Format_Source2   = Format(2)
+	Format_Bzip2     = Format(3)
+	Format_Gzip      = Format(4)
+	Format_Zstd      = Format(5)
+	Format_Broadcast = Format(6)
+)
+
+var formatNames = map[Format]string{
+	Format_Unknown:   "unknown",
+	Format_Source1:   "source 1 replay",
+	Format_Source2:   "source 2 replay",
+	Format_Bzip2:     "bzip2",
+	Format_Gzip:      "gzip",
+	Format_Zstd:      "zstd",
+	Format_Broadcast: "broadcast fragment",
+}
+
+func (f Format) String() string {
+	if name, ok := formatNames[f]; ok {
+		return name
+	}
+	return _sprintf("format %d", int(f))
+}
+
+// Detect the format of replay data from its leading bytes. Recognizes Source 1
+// and Source 2 replays, the compression formats replays are commonly wrapped
+// in, and broadcast fragments, which are demo messages without a header.
+// Compressed data is reported as its compression format. Returns
+// ErrUnexpectedMagic if the format isn't recognized.
+func DetectFormat(buf []byte) (Format, error) {
+	switch {
+	case bytes.HasPrefix(buf, magicSource2):
+		return Format_Source2, nil
+	case bytes.HasPrefix(buf, magicSource1):
+		return Format_Source1, nil
+	case bytes.HasPrefix(buf, magicBzip2):
+		return Format_Bzip2, nil
+	case bytes.HasPrefix(buf, magicGzip):
+		return Format_Gzip, nil
+	case bytes.HasPrefix(buf, magicZstd):
+		return Format_Zstd, nil
+	case isBroadcastFragment(buf):
+		return Format_Broadcast, nil
+	}
+
+	return Format_Unknown, _errorf("%w: unrecognized format", ErrUnexpectedMagic)
+}
+
+// Determines whether or not the data looks like a broadcast fragment, by
+// checking that it starts with a complete demo message of a kind fragments
+// start with. The whole of the first message must be in buf.
+func isBroadcastFragment(buf []byte) bool {
+	// Fragments start with signon messages, packets or a full packet.
+	c, n := binary.Uvarint(buf)
+	if n <= 0 {
+		return false
+	}
+	switch dota.EDemoCommands(c) & ^dota.EDemoCommands_DEM_IsCompressed {
+	case dota.EDemoCommands_DEM_SignonPacket, dota.EDemoCommands_DEM_Packet, dota.EDemoCommands_DEM_FullPacket:
+	default:
+		return false
+	}
+	buf = buf[n:]
+
+	// Followed by a tick, and the size of data that fits in what's left.
+	if _, n = binary.Uvarint(buf); n <= 0 {
+		return false
+	}
+	buf = buf[n:]
+
+	size, n := binary.Uvarint(buf)
+	return n > 0 && size <= uint64(len(buf)-n)
+}
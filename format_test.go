@@ -0,0 +1,61 @@
+package manta
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"testing"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFormat(t *testing.T) {
+	assert := assert.New(t)
+
+	gz := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(gz)
+	gw.Write(makeTestReplayMinimal())
+	gw.Close()
+
+	source1 := append(append([]byte{}, magicSource1...), make([]byte, 8)...)
+
+	scenarios := []struct {
+		buf    []byte
+		format Format
+	}{
+		{makeTestReplayMinimal(), Format_Source2},
+		{source1, Format_Source1},
+		{[]byte("BZh91AY&SY"), Format_Bzip2},
+		{gz.Bytes(), Format_Gzip},
+		{magicZstd, Format_Zstd},
+		{makeTestReplay(testOuterMessage{dota.EDemoCommands_DEM_Packet, 10, &dota.CDemoPacket{}})[16:], Format_Broadcast},
+		{makeTestReplay(testOuterMessage{dota.EDemoCommands_DEM_FullPacket, 10, makeTestFullPacket("a")})[16:], Format_Broadcast},
+		{[]byte("<html>"), Format_Unknown},
+		{[]byte("Hello world"), Format_Unknown},
+		{[]byte("Bob"), Format_Unknown},
+		{[]byte("ID3\x04\x00\x00\x00\x00\x00\x23"), Format_Unknown},
+		{makeTestReplayMinimal()[16:], Format_Unknown}, // not a fragment command
+		{makeTestReplay(testOuterMessage{dota.EDemoCommands_DEM_Packet, 10, &dota.CDemoPacket{Data: make([]byte, 100)}})[16:40], Format_Unknown}, // incomplete
+		{[]byte{}, Format_Unknown},
+	}
+
+	for _, s := range scenarios {
+		format, err := DetectFormat(s.buf)
+		assert.Equal(s.format, format, s.format.String())
+		if s.format == Format_Unknown {
+			assert.True(errors.Is(err, ErrUnexpectedMagic))
+		} else {
+			assert.NoError(err, s.format.String())
+		}
+	}
+}
+
+func TestErrSource1Replay(t *testing.T) {
+	assert := assert.New(t)
+
+	source1 := append(append([]byte{}, magicSource1...), make([]byte, 8)...)
+	_, err := NewParser(source1)
+	assert.Equal(ErrSource1Replay, err)
+	assert.True(errors.Is(err, ErrUnexpectedMagic))
+}
@@ -116,8 +116,6 @@ func main() {
 		`//go:generate go run gen/message_lookup.go %s %s
 package manta
 import (
-  "fmt"
-
   "github.com/dotabuff/manta/dota"
   "github.com/golang/protobuf/proto"
 )
@@ -128,6 +126,9 @@ import (
 	switches := []string{}
 	demSwitches := []string{}
 	subscribeAll := []string{}
+	demRegistry := []string{}
+	packetWants := []string{}
+	packetRegistry := []string{}
 	onFns := []string{}
 	onFnNames := make(map[string]bool)
 	packetTypeIds := make([]int, 0)
@@ -200,9 +201,15 @@ import (
 
 			fnsig := spew.Sprintf("func (*%s) error", cbType)
 
+			msgEnt := "onPacketMessage"
+			if enum.Hook == "DEM" {
+				msgEnt = "onDemoMessage"
+			}
+
 			swtch := spew.Sprintf(
 				`case %d: // dota.%s
-          if cbs := callbacks.%s; cbs != nil {
+          cbs, mcbs := callbacks.%s, callbacks.%s[%d]
+          if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
             msg := &%s{}
             if err := proto.Unmarshal(raw, msg); err != nil {
               return err
@@ -212,8 +219,18 @@ import (
                 return err
               }
             }
+            return p.callMessage(callbacks, mcbs, msg)
           }
-        return nil`, enum.Values[e], e, cbEnt, cbType)
+        return nil`, enum.Values[e], e, cbEnt, msgEnt, enum.Values[e], cbType)
+
+			registryEnt := spew.Sprintf(`%d: func() proto.Message { return &%s{} },`, enum.Values[e], cbType)
+			if enum.Hook == "DEM" {
+				demRegistry = append(demRegistry, registryEnt)
+			} else {
+				packetRegistry = append(packetRegistry, registryEnt)
+				packetWants = append(packetWants, spew.Sprintf(
+					`case %d: return c.%s != nil || c.onPacketMessage[%d] != nil`, enum.Values[e], cbEnt, enum.Values[e]))
+			}
 
 			onfn := spew.Sprintf(
 				`func (c *Callbacks) %s(fn %s) {
@@ -245,26 +262,71 @@ import (
 	}
 	file.WriteString(spew.Sprintf("}\n"))
 
+	file.WriteString(spew.Sprintf(`
+// Constructors for outer message types, by demo type id.
+var demoMessageTypes = map[int32]func() proto.Message{
+  %s
+}
+
+// Constructors for inner packet types, by packet type id.
+var packetMessageTypes = map[int32]func() proto.Message{
+  %s
+}
+`, strings.Join(demRegistry, "\n"), strings.Join(packetRegistry, "\n")))
+
 	file.WriteString(spew.Sprintf(`
 type Callbacks struct {
   %s
+
+  onUnknownDemo []func(uint32, int32, []byte) error
+  onUnknownPacket []func(uint32, int32, []byte) error
+
+  onDemoMessage map[int32][]func(proto.Message) error
+  onPacketMessage map[int32][]func(proto.Message) error
+  onMessage []func(uint32, proto.Message) error
 }
   `, strings.Join(rawMsg, "\n")))
 
 	callTemplate := `
 func (p *Parser) %s(t int32, raw []byte) (error) {
-  callbacks := p.Callbacks
+  callbacks := p.dispatchCallbacks()
   switch t {
   %s
   }
-  return fmt.Errorf("no type found: %%d", t)
+  return p.callUnknownMessage(callbacks.%s, t, raw)
 }
   `
 
 	file.WriteString(strings.Join(onFns, "\n"))
 
-	file.WriteString(spew.Sprintf(callTemplate, "CallByDemoType", strings.Join(demSwitches, "\n")))
-	file.WriteString(spew.Sprintf(callTemplate, "CallByPacketType", strings.Join(switches, "\n")))
+	file.WriteString(`
+// Registers a callback for outer messages of a type without a definition.
+func (c *Callbacks) OnUnknownDemo(fn func(tick uint32, t int32, raw []byte) error) {
+  c.onUnknownDemo = append(c.onUnknownDemo, fn)
+}
+
+// Registers a callback for inner packets of a type without a definition.
+func (c *Callbacks) OnUnknownPacket(fn func(tick uint32, t int32, raw []byte) error) {
+  c.onUnknownPacket = append(c.onUnknownPacket, fn)
+}
+`)
+
+	file.WriteString(spew.Sprintf(callTemplate, "CallByDemoType", strings.Join(demSwitches, "\n"), "onUnknownDemo"))
+	file.WriteString(spew.Sprintf(callTemplate, "CallByPacketType", strings.Join(switches, "\n"), "onUnknownPacket"))
+
+	file.WriteString(spew.Sprintf(`
+// Determines whether or not any callbacks are registered for an inner packet
+// type, so that packets nobody is listening to can be skipped unread.
+func (c *Callbacks) hasPacketCallbacks(t int32) bool {
+  if c.onMessage != nil {
+    return true
+  }
+  switch t {
+  %s
+  }
+  return c.onUnknownPacket != nil
+}
+`, strings.Join(packetWants, "\n")))
 
 	file.WriteString(spew.Sprintf(`
 func (c *Callbacks) OnAny(all func(interface{}) error) {
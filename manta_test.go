@@ -330,11 +330,11 @@ func (s testScenario) test(t *testing.T) {
 	assert := assert.New(t)
 
 	if s.debugTick == 0 {
-		debugLevel = s.debugLevel
+		setDebugLevel(s.debugLevel)
 	}
 
 	defer func() {
-		debugLevel = 0
+		setDebugLevel(0)
 	}()
 
 	buf := mustGetReplayData(s.matchId, s.replayUrl)
@@ -362,7 +362,7 @@ func (s testScenario) test(t *testing.T) {
 	if s.debugTick > 0 {
 		parser.Callbacks.OnCNETMsg_Tick(func(m *dota.CNETMsg_Tick) error {
 			if parser.Tick >= s.debugTick {
-				debugLevel = s.debugLevel
+				setDebugLevel(s.debugLevel)
 			}
 			return nil
 		})
@@ -2,8 +2,6 @@
 package manta
 
 import (
-	"fmt"
-
 	"github.com/dotabuff/manta/dota"
 	"github.com/golang/protobuf/proto"
 )
@@ -191,6 +189,205 @@ var packetNames = map[int32]string{
 	554: "EDotaUserMessages_DOTA_UM_CombatLogDataHLTV",
 }
 
+// Constructors for outer message types, by demo type id.
+var demoMessageTypes = map[int32]func() proto.Message{
+	0:  func() proto.Message { return &dota.CDemoStop{} },
+	1:  func() proto.Message { return &dota.CDemoFileHeader{} },
+	2:  func() proto.Message { return &dota.CDemoFileInfo{} },
+	3:  func() proto.Message { return &dota.CDemoSyncTick{} },
+	4:  func() proto.Message { return &dota.CDemoSendTables{} },
+	5:  func() proto.Message { return &dota.CDemoClassInfo{} },
+	6:  func() proto.Message { return &dota.CDemoStringTables{} },
+	7:  func() proto.Message { return &dota.CDemoPacket{} },
+	8:  func() proto.Message { return &dota.CDemoPacket{} },
+	9:  func() proto.Message { return &dota.CDemoConsoleCmd{} },
+	10: func() proto.Message { return &dota.CDemoCustomData{} },
+	11: func() proto.Message { return &dota.CDemoCustomDataCallbacks{} },
+	12: func() proto.Message { return &dota.CDemoUserCmd{} },
+	13: func() proto.Message { return &dota.CDemoFullPacket{} },
+	14: func() proto.Message { return &dota.CDemoSaveGame{} },
+	15: func() proto.Message { return &dota.CDemoSpawnGroups{} },
+}
+
+// Constructors for inner packet types, by packet type id.
+var packetMessageTypes = map[int32]func() proto.Message{
+	0:   func() proto.Message { return &dota.CNETMsg_NOP{} },
+	1:   func() proto.Message { return &dota.CNETMsg_Disconnect{} },
+	3:   func() proto.Message { return &dota.CNETMsg_SplitScreenUser{} },
+	4:   func() proto.Message { return &dota.CNETMsg_Tick{} },
+	5:   func() proto.Message { return &dota.CNETMsg_StringCmd{} },
+	6:   func() proto.Message { return &dota.CNETMsg_SetConVar{} },
+	7:   func() proto.Message { return &dota.CNETMsg_SignonState{} },
+	8:   func() proto.Message { return &dota.CNETMsg_SpawnGroup_Load{} },
+	9:   func() proto.Message { return &dota.CNETMsg_SpawnGroup_ManifestUpdate{} },
+	11:  func() proto.Message { return &dota.CNETMsg_SpawnGroup_SetCreationTick{} },
+	12:  func() proto.Message { return &dota.CNETMsg_SpawnGroup_Unload{} },
+	13:  func() proto.Message { return &dota.CNETMsg_SpawnGroup_LoadCompleted{} },
+	40:  func() proto.Message { return &dota.CSVCMsg_ServerInfo{} },
+	41:  func() proto.Message { return &dota.CSVCMsg_FlattenedSerializer{} },
+	42:  func() proto.Message { return &dota.CSVCMsg_ClassInfo{} },
+	43:  func() proto.Message { return &dota.CSVCMsg_SetPause{} },
+	44:  func() proto.Message { return &dota.CSVCMsg_CreateStringTable{} },
+	45:  func() proto.Message { return &dota.CSVCMsg_UpdateStringTable{} },
+	46:  func() proto.Message { return &dota.CSVCMsg_VoiceInit{} },
+	47:  func() proto.Message { return &dota.CSVCMsg_VoiceData{} },
+	48:  func() proto.Message { return &dota.CSVCMsg_Print{} },
+	49:  func() proto.Message { return &dota.CSVCMsg_Sounds{} },
+	50:  func() proto.Message { return &dota.CSVCMsg_SetView{} },
+	51:  func() proto.Message { return &dota.CSVCMsg_ClearAllStringTables{} },
+	52:  func() proto.Message { return &dota.CSVCMsg_CmdKeyValues{} },
+	53:  func() proto.Message { return &dota.CSVCMsg_BSPDecal{} },
+	54:  func() proto.Message { return &dota.CSVCMsg_SplitScreen{} },
+	55:  func() proto.Message { return &dota.CSVCMsg_PacketEntities{} },
+	56:  func() proto.Message { return &dota.CSVCMsg_Prefetch{} },
+	57:  func() proto.Message { return &dota.CSVCMsg_Menu{} },
+	58:  func() proto.Message { return &dota.CSVCMsg_GetCvarValue{} },
+	59:  func() proto.Message { return &dota.CSVCMsg_StopSound{} },
+	60:  func() proto.Message { return &dota.CSVCMsg_PeerList{} },
+	61:  func() proto.Message { return &dota.CSVCMsg_PacketReliable{} },
+	62:  func() proto.Message { return &dota.CSVCMsg_HLTVStatus{} },
+	70:  func() proto.Message { return &dota.CSVCMsg_FullFrameSplit{} },
+	101: func() proto.Message { return &dota.CUserMessageAchievementEvent{} },
+	102: func() proto.Message { return &dota.CUserMessageCloseCaption{} },
+	103: func() proto.Message { return &dota.CUserMessageCloseCaptionDirect{} },
+	104: func() proto.Message { return &dota.CUserMessageCurrentTimescale{} },
+	105: func() proto.Message { return &dota.CUserMessageDesiredTimescale{} },
+	106: func() proto.Message { return &dota.CUserMessageFade{} },
+	107: func() proto.Message { return &dota.CUserMessageGameTitle{} },
+	109: func() proto.Message { return &dota.CUserMessageHintText{} },
+	110: func() proto.Message { return &dota.CUserMessageHudMsg{} },
+	111: func() proto.Message { return &dota.CUserMessageHudText{} },
+	112: func() proto.Message { return &dota.CUserMessageKeyHintText{} },
+	113: func() proto.Message { return &dota.CUserMessageColoredText{} },
+	114: func() proto.Message { return &dota.CUserMessageRequestState{} },
+	115: func() proto.Message { return &dota.CUserMessageResetHUD{} },
+	116: func() proto.Message { return &dota.CUserMessageRumble{} },
+	117: func() proto.Message { return &dota.CUserMessageSayText{} },
+	118: func() proto.Message { return &dota.CUserMessageSayText2{} },
+	119: func() proto.Message { return &dota.CUserMessageSayTextChannel{} },
+	120: func() proto.Message { return &dota.CUserMessageShake{} },
+	121: func() proto.Message { return &dota.CUserMessageShakeDir{} },
+	124: func() proto.Message { return &dota.CUserMessageTextMsg{} },
+	125: func() proto.Message { return &dota.CUserMessageScreenTilt{} },
+	126: func() proto.Message { return &dota.CUserMessageTrain{} },
+	127: func() proto.Message { return &dota.CUserMessageVGUIMenu{} },
+	128: func() proto.Message { return &dota.CUserMessageVoiceMask{} },
+	129: func() proto.Message { return &dota.CUserMessageVoiceSubtitle{} },
+	130: func() proto.Message { return &dota.CUserMessageSendAudio{} },
+	131: func() proto.Message { return &dota.CUserMessageItemPickup{} },
+	132: func() proto.Message { return &dota.CUserMessageAmmoDenied{} },
+	133: func() proto.Message { return &dota.CUserMessageCrosshairAngle{} },
+	134: func() proto.Message { return &dota.CUserMessageShowMenu{} },
+	135: func() proto.Message { return &dota.CUserMessageCreditsMsg{} },
+	142: func() proto.Message { return &dota.CUserMessageCloseCaptionPlaceholder{} },
+	143: func() proto.Message { return &dota.CUserMessageCameraTransition{} },
+	144: func() proto.Message { return &dota.CUserMessageAudioParameter{} },
+	136: func() proto.Message { return &dota.CEntityMessagePlayJingle{} },
+	137: func() proto.Message { return &dota.CEntityMessageScreenOverlay{} },
+	138: func() proto.Message { return &dota.CEntityMessageRemoveAllDecals{} },
+	139: func() proto.Message { return &dota.CEntityMessagePropagateForce{} },
+	140: func() proto.Message { return &dota.CEntityMessageDoSpark{} },
+	141: func() proto.Message { return &dota.CEntityMessageFixAngle{} },
+	200: func() proto.Message { return &dota.CMsgVDebugGameSessionIDEvent{} },
+	201: func() proto.Message { return &dota.CMsgPlaceDecalEvent{} },
+	202: func() proto.Message { return &dota.CMsgClearWorldDecalsEvent{} },
+	203: func() proto.Message { return &dota.CMsgClearEntityDecalsEvent{} },
+	204: func() proto.Message { return &dota.CMsgClearDecalsForSkeletonInstanceEvent{} },
+	205: func() proto.Message { return &dota.CMsgSource1LegacyGameEventList{} },
+	206: func() proto.Message { return &dota.CMsgSource1LegacyListenEvents{} },
+	207: func() proto.Message { return &dota.CMsgSource1LegacyGameEvent{} },
+	208: func() proto.Message { return &dota.CMsgSosStartSoundEvent{} },
+	209: func() proto.Message { return &dota.CMsgSosStopSoundEvent{} },
+	210: func() proto.Message { return &dota.CMsgSosSetSoundEventParams{} },
+	211: func() proto.Message { return &dota.CMsgSosSetLibraryStackFields{} },
+	212: func() proto.Message { return &dota.CMsgSosStopSoundEventHash{} },
+	465: func() proto.Message { return &dota.CDOTAUserMsg_AIDebugLine{} },
+	466: func() proto.Message { return &dota.CDOTAUserMsg_ChatEvent{} },
+	467: func() proto.Message { return &dota.CDOTAUserMsg_CombatHeroPositions{} },
+	470: func() proto.Message { return &dota.CDOTAUserMsg_CombatLogShowDeath{} },
+	471: func() proto.Message { return &dota.CDOTAUserMsg_CreateLinearProjectile{} },
+	472: func() proto.Message { return &dota.CDOTAUserMsg_DestroyLinearProjectile{} },
+	473: func() proto.Message { return &dota.CDOTAUserMsg_DodgeTrackingProjectiles{} },
+	474: func() proto.Message { return &dota.CDOTAUserMsg_GlobalLightColor{} },
+	475: func() proto.Message { return &dota.CDOTAUserMsg_GlobalLightDirection{} },
+	476: func() proto.Message { return &dota.CDOTAUserMsg_InvalidCommand{} },
+	477: func() proto.Message { return &dota.CDOTAUserMsg_LocationPing{} },
+	478: func() proto.Message { return &dota.CDOTAUserMsg_MapLine{} },
+	479: func() proto.Message { return &dota.CDOTAUserMsg_MiniKillCamInfo{} },
+	480: func() proto.Message { return &dota.CDOTAUserMsg_MinimapDebugPoint{} },
+	481: func() proto.Message { return &dota.CDOTAUserMsg_MinimapEvent{} },
+	482: func() proto.Message { return &dota.CDOTAUserMsg_NevermoreRequiem{} },
+	483: func() proto.Message { return &dota.CDOTAUserMsg_OverheadEvent{} },
+	484: func() proto.Message { return &dota.CDOTAUserMsg_SetNextAutobuyItem{} },
+	485: func() proto.Message { return &dota.CDOTAUserMsg_SharedCooldown{} },
+	486: func() proto.Message { return &dota.CDOTAUserMsg_SpectatorPlayerClick{} },
+	487: func() proto.Message { return &dota.CDOTAUserMsg_TutorialTipInfo{} },
+	488: func() proto.Message { return &dota.CDOTAUserMsg_UnitEvent{} },
+	489: func() proto.Message { return &dota.CDOTAUserMsg_ParticleManager{} },
+	490: func() proto.Message { return &dota.CDOTAUserMsg_BotChat{} },
+	491: func() proto.Message { return &dota.CDOTAUserMsg_HudError{} },
+	492: func() proto.Message { return &dota.CDOTAUserMsg_ItemPurchased{} },
+	493: func() proto.Message { return &dota.CDOTAUserMsg_Ping{} },
+	494: func() proto.Message { return &dota.CDOTAUserMsg_ItemFound{} },
+	496: func() proto.Message { return &dota.CDOTAUserMsg_SwapVerify{} },
+	497: func() proto.Message { return &dota.CDOTAUserMsg_WorldLine{} },
+	499: func() proto.Message { return &dota.CDOTAUserMsg_ItemAlert{} },
+	500: func() proto.Message { return &dota.CDOTAUserMsg_HalloweenDrops{} },
+	501: func() proto.Message { return &dota.CDOTAUserMsg_ChatWheel{} },
+	502: func() proto.Message { return &dota.CDOTAUserMsg_ReceivedXmasGift{} },
+	503: func() proto.Message { return &dota.CDOTAUserMsg_UpdateSharedContent{} },
+	504: func() proto.Message { return &dota.CDOTAUserMsg_TutorialRequestExp{} },
+	505: func() proto.Message { return &dota.CDOTAUserMsg_TutorialPingMinimap{} },
+	506: func() proto.Message { return &dota.CDOTAUserMsg_GamerulesStateChanged{} },
+	507: func() proto.Message { return &dota.CDOTAUserMsg_ShowSurvey{} },
+	508: func() proto.Message { return &dota.CDOTAUserMsg_TutorialFade{} },
+	509: func() proto.Message { return &dota.CDOTAUserMsg_AddQuestLogEntry{} },
+	510: func() proto.Message { return &dota.CDOTAUserMsg_SendStatPopup{} },
+	511: func() proto.Message { return &dota.CDOTAUserMsg_TutorialFinish{} },
+	512: func() proto.Message { return &dota.CDOTAUserMsg_SendRoshanPopup{} },
+	513: func() proto.Message { return &dota.CDOTAUserMsg_SendGenericToolTip{} },
+	514: func() proto.Message { return &dota.CDOTAUserMsg_SendFinalGold{} },
+	515: func() proto.Message { return &dota.CDOTAUserMsg_CustomMsg{} },
+	516: func() proto.Message { return &dota.CDOTAUserMsg_CoachHUDPing{} },
+	517: func() proto.Message { return &dota.CDOTAUserMsg_ClientLoadGridNav{} },
+	518: func() proto.Message { return &dota.CDOTAUserMsg_TE_Projectile{} },
+	519: func() proto.Message { return &dota.CDOTAUserMsg_TE_ProjectileLoc{} },
+	520: func() proto.Message { return &dota.CDOTAUserMsg_TE_DotaBloodImpact{} },
+	521: func() proto.Message { return &dota.CDOTAUserMsg_TE_UnitAnimation{} },
+	522: func() proto.Message { return &dota.CDOTAUserMsg_TE_UnitAnimationEnd{} },
+	523: func() proto.Message { return &dota.CDOTAUserMsg_AbilityPing{} },
+	524: func() proto.Message { return &dota.CDOTAUserMsg_ShowGenericPopup{} },
+	525: func() proto.Message { return &dota.CDOTAUserMsg_VoteStart{} },
+	526: func() proto.Message { return &dota.CDOTAUserMsg_VoteUpdate{} },
+	527: func() proto.Message { return &dota.CDOTAUserMsg_VoteEnd{} },
+	528: func() proto.Message { return &dota.CDOTAUserMsg_BoosterState{} },
+	529: func() proto.Message { return &dota.CDOTAUserMsg_WillPurchaseAlert{} },
+	530: func() proto.Message { return &dota.CDOTAUserMsg_TutorialMinimapPosition{} },
+	531: func() proto.Message { return &dota.CDOTAUserMsg_PlayerMMR{} },
+	532: func() proto.Message { return &dota.CDOTAUserMsg_AbilitySteal{} },
+	533: func() proto.Message { return &dota.CDOTAUserMsg_CourierKilledAlert{} },
+	534: func() proto.Message { return &dota.CDOTAUserMsg_EnemyItemAlert{} },
+	535: func() proto.Message { return &dota.CDOTAUserMsg_StatsMatchDetails{} },
+	536: func() proto.Message { return &dota.CDOTAUserMsg_MiniTaunt{} },
+	537: func() proto.Message { return &dota.CDOTAUserMsg_BuyBackStateAlert{} },
+	538: func() proto.Message { return &dota.CDOTAUserMsg_SpeechBubble{} },
+	539: func() proto.Message { return &dota.CDOTAUserMsg_CustomHeaderMessage{} },
+	540: func() proto.Message { return &dota.CDOTAUserMsg_QuickBuyAlert{} },
+	542: func() proto.Message { return &dota.CDOTAUserMsg_PredictionResult{} },
+	543: func() proto.Message { return &dota.CDOTAUserMsg_ModifierAlert{} },
+	544: func() proto.Message { return &dota.CDOTAUserMsg_HPManaAlert{} },
+	545: func() proto.Message { return &dota.CDOTAUserMsg_GlyphAlert{} },
+	546: func() proto.Message { return &dota.CDOTAUserMsg_BeastChat{} },
+	547: func() proto.Message { return &dota.CDOTAUserMsg_SpectatorPlayerUnitOrders{} },
+	548: func() proto.Message { return &dota.CDOTAUserMsg_CustomHudElement_Create{} },
+	549: func() proto.Message { return &dota.CDOTAUserMsg_CustomHudElement_Modify{} },
+	550: func() proto.Message { return &dota.CDOTAUserMsg_CustomHudElement_Destroy{} },
+	551: func() proto.Message { return &dota.CDOTAUserMsg_CompendiumState{} },
+	552: func() proto.Message { return &dota.CDOTAUserMsg_ProjectionAbility{} },
+	553: func() proto.Message { return &dota.CDOTAUserMsg_ProjectionEvent{} },
+	554: func() proto.Message { return &dota.CMsgDOTACombatLogEntry{} },
+}
+
 type Callbacks struct {
 	onCDemoStop                               []func(*dota.CDemoStop) error
 	onCDemoFileHeader                         []func(*dota.CDemoFileHeader) error
@@ -383,6 +580,13 @@ type Callbacks struct {
 	onCDOTAUserMsg_ProjectionAbility          []func(*dota.CDOTAUserMsg_ProjectionAbility) error
 	onCDOTAUserMsg_ProjectionEvent            []func(*dota.CDOTAUserMsg_ProjectionEvent) error
 	onCMsgDOTACombatLogEntry                  []func(*dota.CMsgDOTACombatLogEntry) error
+
+	onUnknownDemo   []func(uint32, int32, []byte) error
+	onUnknownPacket []func(uint32, int32, []byte) error
+
+	onDemoMessage   map[int32][]func(proto.Message) error
+	onPacketMessage map[int32][]func(proto.Message) error
+	onMessage       []func(uint32, proto.Message) error
 }
 
 func (c *Callbacks) OnCDemoStop(fn func(*dota.CDemoStop) error) {
@@ -958,11 +1162,23 @@ func (c *Callbacks) OnCDOTAUserMsg_ProjectionEvent(fn func(*dota.CDOTAUserMsg_Pr
 func (c *Callbacks) OnCMsgDOTACombatLogEntry(fn func(*dota.CMsgDOTACombatLogEntry) error) {
 	c.onCMsgDOTACombatLogEntry = append(c.onCMsgDOTACombatLogEntry, fn)
 }
+
+// Registers a callback for outer messages of a type without a definition.
+func (c *Callbacks) OnUnknownDemo(fn func(tick uint32, t int32, raw []byte) error) {
+	c.onUnknownDemo = append(c.onUnknownDemo, fn)
+}
+
+// Registers a callback for inner packets of a type without a definition.
+func (c *Callbacks) OnUnknownPacket(fn func(tick uint32, t int32, raw []byte) error) {
+	c.onUnknownPacket = append(c.onUnknownPacket, fn)
+}
+
 func (p *Parser) CallByDemoType(t int32, raw []byte) error {
-	callbacks := p.Callbacks
+	callbacks := p.dispatchCallbacks()
 	switch t {
 	case 0: // dota.EDemoCommands_DEM_Stop
-		if cbs := callbacks.onCDemoStop; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoStop, callbacks.onDemoMessage[0]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoStop{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -972,10 +1188,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 1: // dota.EDemoCommands_DEM_FileHeader
-		if cbs := callbacks.onCDemoFileHeader; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoFileHeader, callbacks.onDemoMessage[1]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoFileHeader{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -985,10 +1203,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 2: // dota.EDemoCommands_DEM_FileInfo
-		if cbs := callbacks.onCDemoFileInfo; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoFileInfo, callbacks.onDemoMessage[2]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoFileInfo{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -998,10 +1218,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 3: // dota.EDemoCommands_DEM_SyncTick
-		if cbs := callbacks.onCDemoSyncTick; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoSyncTick, callbacks.onDemoMessage[3]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoSyncTick{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1011,10 +1233,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 4: // dota.EDemoCommands_DEM_SendTables
-		if cbs := callbacks.onCDemoSendTables; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoSendTables, callbacks.onDemoMessage[4]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoSendTables{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1024,10 +1248,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 5: // dota.EDemoCommands_DEM_ClassInfo
-		if cbs := callbacks.onCDemoClassInfo; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoClassInfo, callbacks.onDemoMessage[5]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoClassInfo{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1037,10 +1263,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 6: // dota.EDemoCommands_DEM_StringTables
-		if cbs := callbacks.onCDemoStringTables; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoStringTables, callbacks.onDemoMessage[6]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoStringTables{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1050,10 +1278,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 7: // dota.EDemoCommands_DEM_Packet
-		if cbs := callbacks.onCDemoPacket; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoPacket, callbacks.onDemoMessage[7]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoPacket{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1063,10 +1293,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 8: // dota.EDemoCommands_DEM_SignonPacket
-		if cbs := callbacks.onCDemoSignonPacket; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoSignonPacket, callbacks.onDemoMessage[8]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoPacket{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1076,10 +1308,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 9: // dota.EDemoCommands_DEM_ConsoleCmd
-		if cbs := callbacks.onCDemoConsoleCmd; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoConsoleCmd, callbacks.onDemoMessage[9]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoConsoleCmd{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1089,10 +1323,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 10: // dota.EDemoCommands_DEM_CustomData
-		if cbs := callbacks.onCDemoCustomData; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoCustomData, callbacks.onDemoMessage[10]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoCustomData{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1102,10 +1338,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 11: // dota.EDemoCommands_DEM_CustomDataCallbacks
-		if cbs := callbacks.onCDemoCustomDataCallbacks; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoCustomDataCallbacks, callbacks.onDemoMessage[11]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoCustomDataCallbacks{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1115,10 +1353,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 12: // dota.EDemoCommands_DEM_UserCmd
-		if cbs := callbacks.onCDemoUserCmd; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoUserCmd, callbacks.onDemoMessage[12]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoUserCmd{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1128,10 +1368,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 13: // dota.EDemoCommands_DEM_FullPacket
-		if cbs := callbacks.onCDemoFullPacket; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoFullPacket, callbacks.onDemoMessage[13]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoFullPacket{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1141,10 +1383,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 14: // dota.EDemoCommands_DEM_SaveGame
-		if cbs := callbacks.onCDemoSaveGame; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoSaveGame, callbacks.onDemoMessage[14]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoSaveGame{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1154,10 +1398,12 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 15: // dota.EDemoCommands_DEM_SpawnGroups
-		if cbs := callbacks.onCDemoSpawnGroups; cbs != nil {
+		cbs, mcbs := callbacks.onCDemoSpawnGroups, callbacks.onDemoMessage[15]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDemoSpawnGroups{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1167,17 +1413,19 @@ func (p *Parser) CallByDemoType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	}
-	return fmt.Errorf("no type found: %d", t)
+	return p.callUnknownMessage(callbacks.onUnknownDemo, t, raw)
 }
 
 func (p *Parser) CallByPacketType(t int32, raw []byte) error {
-	callbacks := p.Callbacks
+	callbacks := p.dispatchCallbacks()
 	switch t {
 	case 0: // dota.NET_Messages_net_NOP
-		if cbs := callbacks.onCNETMsg_NOP; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_NOP, callbacks.onPacketMessage[0]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_NOP{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1187,10 +1435,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 1: // dota.NET_Messages_net_Disconnect
-		if cbs := callbacks.onCNETMsg_Disconnect; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_Disconnect, callbacks.onPacketMessage[1]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_Disconnect{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1200,10 +1450,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 3: // dota.NET_Messages_net_SplitScreenUser
-		if cbs := callbacks.onCNETMsg_SplitScreenUser; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_SplitScreenUser, callbacks.onPacketMessage[3]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_SplitScreenUser{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1213,10 +1465,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 4: // dota.NET_Messages_net_Tick
-		if cbs := callbacks.onCNETMsg_Tick; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_Tick, callbacks.onPacketMessage[4]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_Tick{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1226,10 +1480,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 5: // dota.NET_Messages_net_StringCmd
-		if cbs := callbacks.onCNETMsg_StringCmd; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_StringCmd, callbacks.onPacketMessage[5]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_StringCmd{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1239,10 +1495,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 6: // dota.NET_Messages_net_SetConVar
-		if cbs := callbacks.onCNETMsg_SetConVar; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_SetConVar, callbacks.onPacketMessage[6]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_SetConVar{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1252,10 +1510,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 7: // dota.NET_Messages_net_SignonState
-		if cbs := callbacks.onCNETMsg_SignonState; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_SignonState, callbacks.onPacketMessage[7]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_SignonState{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1265,10 +1525,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 8: // dota.NET_Messages_net_SpawnGroup_Load
-		if cbs := callbacks.onCNETMsg_SpawnGroup_Load; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_SpawnGroup_Load, callbacks.onPacketMessage[8]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_SpawnGroup_Load{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1278,10 +1540,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 9: // dota.NET_Messages_net_SpawnGroup_ManifestUpdate
-		if cbs := callbacks.onCNETMsg_SpawnGroup_ManifestUpdate; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_SpawnGroup_ManifestUpdate, callbacks.onPacketMessage[9]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_SpawnGroup_ManifestUpdate{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1291,10 +1555,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 11: // dota.NET_Messages_net_SpawnGroup_SetCreationTick
-		if cbs := callbacks.onCNETMsg_SpawnGroup_SetCreationTick; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_SpawnGroup_SetCreationTick, callbacks.onPacketMessage[11]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_SpawnGroup_SetCreationTick{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1304,10 +1570,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 12: // dota.NET_Messages_net_SpawnGroup_Unload
-		if cbs := callbacks.onCNETMsg_SpawnGroup_Unload; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_SpawnGroup_Unload, callbacks.onPacketMessage[12]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_SpawnGroup_Unload{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1317,10 +1585,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 13: // dota.NET_Messages_net_SpawnGroup_LoadCompleted
-		if cbs := callbacks.onCNETMsg_SpawnGroup_LoadCompleted; cbs != nil {
+		cbs, mcbs := callbacks.onCNETMsg_SpawnGroup_LoadCompleted, callbacks.onPacketMessage[13]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CNETMsg_SpawnGroup_LoadCompleted{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1330,10 +1600,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 40: // dota.SVC_Messages_svc_ServerInfo
-		if cbs := callbacks.onCSVCMsg_ServerInfo; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_ServerInfo, callbacks.onPacketMessage[40]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_ServerInfo{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1343,10 +1615,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 41: // dota.SVC_Messages_svc_FlattenedSerializer
-		if cbs := callbacks.onCSVCMsg_FlattenedSerializer; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_FlattenedSerializer, callbacks.onPacketMessage[41]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_FlattenedSerializer{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1356,10 +1630,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 42: // dota.SVC_Messages_svc_ClassInfo
-		if cbs := callbacks.onCSVCMsg_ClassInfo; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_ClassInfo, callbacks.onPacketMessage[42]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_ClassInfo{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1369,10 +1645,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 43: // dota.SVC_Messages_svc_SetPause
-		if cbs := callbacks.onCSVCMsg_SetPause; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_SetPause, callbacks.onPacketMessage[43]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_SetPause{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1382,10 +1660,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 44: // dota.SVC_Messages_svc_CreateStringTable
-		if cbs := callbacks.onCSVCMsg_CreateStringTable; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_CreateStringTable, callbacks.onPacketMessage[44]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_CreateStringTable{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1395,10 +1675,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 45: // dota.SVC_Messages_svc_UpdateStringTable
-		if cbs := callbacks.onCSVCMsg_UpdateStringTable; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_UpdateStringTable, callbacks.onPacketMessage[45]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_UpdateStringTable{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1408,10 +1690,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 46: // dota.SVC_Messages_svc_VoiceInit
-		if cbs := callbacks.onCSVCMsg_VoiceInit; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_VoiceInit, callbacks.onPacketMessage[46]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_VoiceInit{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1421,10 +1705,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 47: // dota.SVC_Messages_svc_VoiceData
-		if cbs := callbacks.onCSVCMsg_VoiceData; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_VoiceData, callbacks.onPacketMessage[47]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_VoiceData{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1434,10 +1720,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 48: // dota.SVC_Messages_svc_Print
-		if cbs := callbacks.onCSVCMsg_Print; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_Print, callbacks.onPacketMessage[48]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_Print{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1447,10 +1735,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 49: // dota.SVC_Messages_svc_Sounds
-		if cbs := callbacks.onCSVCMsg_Sounds; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_Sounds, callbacks.onPacketMessage[49]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_Sounds{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1460,10 +1750,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 50: // dota.SVC_Messages_svc_SetView
-		if cbs := callbacks.onCSVCMsg_SetView; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_SetView, callbacks.onPacketMessage[50]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_SetView{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1473,10 +1765,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 51: // dota.SVC_Messages_svc_ClearAllStringTables
-		if cbs := callbacks.onCSVCMsg_ClearAllStringTables; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_ClearAllStringTables, callbacks.onPacketMessage[51]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_ClearAllStringTables{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1486,10 +1780,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 52: // dota.SVC_Messages_svc_CmdKeyValues
-		if cbs := callbacks.onCSVCMsg_CmdKeyValues; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_CmdKeyValues, callbacks.onPacketMessage[52]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_CmdKeyValues{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1499,10 +1795,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 53: // dota.SVC_Messages_svc_BSPDecal
-		if cbs := callbacks.onCSVCMsg_BSPDecal; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_BSPDecal, callbacks.onPacketMessage[53]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_BSPDecal{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1512,10 +1810,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 54: // dota.SVC_Messages_svc_SplitScreen
-		if cbs := callbacks.onCSVCMsg_SplitScreen; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_SplitScreen, callbacks.onPacketMessage[54]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_SplitScreen{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1525,10 +1825,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 55: // dota.SVC_Messages_svc_PacketEntities
-		if cbs := callbacks.onCSVCMsg_PacketEntities; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_PacketEntities, callbacks.onPacketMessage[55]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_PacketEntities{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1538,10 +1840,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 56: // dota.SVC_Messages_svc_Prefetch
-		if cbs := callbacks.onCSVCMsg_Prefetch; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_Prefetch, callbacks.onPacketMessage[56]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_Prefetch{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1551,10 +1855,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 57: // dota.SVC_Messages_svc_Menu
-		if cbs := callbacks.onCSVCMsg_Menu; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_Menu, callbacks.onPacketMessage[57]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_Menu{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1564,10 +1870,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 58: // dota.SVC_Messages_svc_GetCvarValue
-		if cbs := callbacks.onCSVCMsg_GetCvarValue; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_GetCvarValue, callbacks.onPacketMessage[58]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_GetCvarValue{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1577,10 +1885,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 59: // dota.SVC_Messages_svc_StopSound
-		if cbs := callbacks.onCSVCMsg_StopSound; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_StopSound, callbacks.onPacketMessage[59]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_StopSound{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1590,10 +1900,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 60: // dota.SVC_Messages_svc_PeerList
-		if cbs := callbacks.onCSVCMsg_PeerList; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_PeerList, callbacks.onPacketMessage[60]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_PeerList{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1603,10 +1915,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 61: // dota.SVC_Messages_svc_PacketReliable
-		if cbs := callbacks.onCSVCMsg_PacketReliable; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_PacketReliable, callbacks.onPacketMessage[61]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_PacketReliable{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1616,10 +1930,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 62: // dota.SVC_Messages_svc_HLTVStatus
-		if cbs := callbacks.onCSVCMsg_HLTVStatus; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_HLTVStatus, callbacks.onPacketMessage[62]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_HLTVStatus{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1629,10 +1945,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 70: // dota.SVC_Messages_svc_FullFrameSplit
-		if cbs := callbacks.onCSVCMsg_FullFrameSplit; cbs != nil {
+		cbs, mcbs := callbacks.onCSVCMsg_FullFrameSplit, callbacks.onPacketMessage[70]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CSVCMsg_FullFrameSplit{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1642,10 +1960,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 101: // dota.EBaseUserMessages_UM_AchievementEvent
-		if cbs := callbacks.onCUserMessageAchievementEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageAchievementEvent, callbacks.onPacketMessage[101]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageAchievementEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1655,10 +1975,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 102: // dota.EBaseUserMessages_UM_CloseCaption
-		if cbs := callbacks.onCUserMessageCloseCaption; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageCloseCaption, callbacks.onPacketMessage[102]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageCloseCaption{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1668,10 +1990,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 103: // dota.EBaseUserMessages_UM_CloseCaptionDirect
-		if cbs := callbacks.onCUserMessageCloseCaptionDirect; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageCloseCaptionDirect, callbacks.onPacketMessage[103]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageCloseCaptionDirect{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1681,10 +2005,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 104: // dota.EBaseUserMessages_UM_CurrentTimescale
-		if cbs := callbacks.onCUserMessageCurrentTimescale; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageCurrentTimescale, callbacks.onPacketMessage[104]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageCurrentTimescale{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1694,10 +2020,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 105: // dota.EBaseUserMessages_UM_DesiredTimescale
-		if cbs := callbacks.onCUserMessageDesiredTimescale; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageDesiredTimescale, callbacks.onPacketMessage[105]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageDesiredTimescale{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1707,10 +2035,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 106: // dota.EBaseUserMessages_UM_Fade
-		if cbs := callbacks.onCUserMessageFade; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageFade, callbacks.onPacketMessage[106]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageFade{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1720,10 +2050,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 107: // dota.EBaseUserMessages_UM_GameTitle
-		if cbs := callbacks.onCUserMessageGameTitle; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageGameTitle, callbacks.onPacketMessage[107]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageGameTitle{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1733,10 +2065,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 109: // dota.EBaseUserMessages_UM_HintText
-		if cbs := callbacks.onCUserMessageHintText; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageHintText, callbacks.onPacketMessage[109]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageHintText{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1746,10 +2080,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 110: // dota.EBaseUserMessages_UM_HudMsg
-		if cbs := callbacks.onCUserMessageHudMsg; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageHudMsg, callbacks.onPacketMessage[110]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageHudMsg{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1759,10 +2095,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 111: // dota.EBaseUserMessages_UM_HudText
-		if cbs := callbacks.onCUserMessageHudText; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageHudText, callbacks.onPacketMessage[111]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageHudText{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1772,10 +2110,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 112: // dota.EBaseUserMessages_UM_KeyHintText
-		if cbs := callbacks.onCUserMessageKeyHintText; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageKeyHintText, callbacks.onPacketMessage[112]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageKeyHintText{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1785,10 +2125,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 113: // dota.EBaseUserMessages_UM_ColoredText
-		if cbs := callbacks.onCUserMessageColoredText; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageColoredText, callbacks.onPacketMessage[113]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageColoredText{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1798,10 +2140,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 114: // dota.EBaseUserMessages_UM_RequestState
-		if cbs := callbacks.onCUserMessageRequestState; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageRequestState, callbacks.onPacketMessage[114]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageRequestState{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1811,10 +2155,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 115: // dota.EBaseUserMessages_UM_ResetHUD
-		if cbs := callbacks.onCUserMessageResetHUD; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageResetHUD, callbacks.onPacketMessage[115]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageResetHUD{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1824,10 +2170,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 116: // dota.EBaseUserMessages_UM_Rumble
-		if cbs := callbacks.onCUserMessageRumble; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageRumble, callbacks.onPacketMessage[116]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageRumble{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1837,10 +2185,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 117: // dota.EBaseUserMessages_UM_SayText
-		if cbs := callbacks.onCUserMessageSayText; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageSayText, callbacks.onPacketMessage[117]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageSayText{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1850,10 +2200,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 118: // dota.EBaseUserMessages_UM_SayText2
-		if cbs := callbacks.onCUserMessageSayText2; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageSayText2, callbacks.onPacketMessage[118]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageSayText2{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1863,10 +2215,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 119: // dota.EBaseUserMessages_UM_SayTextChannel
-		if cbs := callbacks.onCUserMessageSayTextChannel; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageSayTextChannel, callbacks.onPacketMessage[119]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageSayTextChannel{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1876,10 +2230,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 120: // dota.EBaseUserMessages_UM_Shake
-		if cbs := callbacks.onCUserMessageShake; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageShake, callbacks.onPacketMessage[120]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageShake{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1889,10 +2245,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 121: // dota.EBaseUserMessages_UM_ShakeDir
-		if cbs := callbacks.onCUserMessageShakeDir; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageShakeDir, callbacks.onPacketMessage[121]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageShakeDir{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1902,10 +2260,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 124: // dota.EBaseUserMessages_UM_TextMsg
-		if cbs := callbacks.onCUserMessageTextMsg; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageTextMsg, callbacks.onPacketMessage[124]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageTextMsg{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1915,10 +2275,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 125: // dota.EBaseUserMessages_UM_ScreenTilt
-		if cbs := callbacks.onCUserMessageScreenTilt; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageScreenTilt, callbacks.onPacketMessage[125]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageScreenTilt{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1928,10 +2290,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 126: // dota.EBaseUserMessages_UM_Train
-		if cbs := callbacks.onCUserMessageTrain; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageTrain, callbacks.onPacketMessage[126]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageTrain{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1941,10 +2305,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 127: // dota.EBaseUserMessages_UM_VGUIMenu
-		if cbs := callbacks.onCUserMessageVGUIMenu; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageVGUIMenu, callbacks.onPacketMessage[127]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageVGUIMenu{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1954,10 +2320,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 128: // dota.EBaseUserMessages_UM_VoiceMask
-		if cbs := callbacks.onCUserMessageVoiceMask; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageVoiceMask, callbacks.onPacketMessage[128]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageVoiceMask{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1967,10 +2335,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 129: // dota.EBaseUserMessages_UM_VoiceSubtitle
-		if cbs := callbacks.onCUserMessageVoiceSubtitle; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageVoiceSubtitle, callbacks.onPacketMessage[129]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageVoiceSubtitle{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1980,10 +2350,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 130: // dota.EBaseUserMessages_UM_SendAudio
-		if cbs := callbacks.onCUserMessageSendAudio; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageSendAudio, callbacks.onPacketMessage[130]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageSendAudio{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -1993,10 +2365,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 131: // dota.EBaseUserMessages_UM_ItemPickup
-		if cbs := callbacks.onCUserMessageItemPickup; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageItemPickup, callbacks.onPacketMessage[131]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageItemPickup{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2006,10 +2380,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 132: // dota.EBaseUserMessages_UM_AmmoDenied
-		if cbs := callbacks.onCUserMessageAmmoDenied; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageAmmoDenied, callbacks.onPacketMessage[132]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageAmmoDenied{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2019,10 +2395,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 133: // dota.EBaseUserMessages_UM_CrosshairAngle
-		if cbs := callbacks.onCUserMessageCrosshairAngle; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageCrosshairAngle, callbacks.onPacketMessage[133]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageCrosshairAngle{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2032,10 +2410,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 134: // dota.EBaseUserMessages_UM_ShowMenu
-		if cbs := callbacks.onCUserMessageShowMenu; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageShowMenu, callbacks.onPacketMessage[134]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageShowMenu{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2045,10 +2425,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 135: // dota.EBaseUserMessages_UM_CreditsMsg
-		if cbs := callbacks.onCUserMessageCreditsMsg; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageCreditsMsg, callbacks.onPacketMessage[135]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageCreditsMsg{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2058,10 +2440,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 142: // dota.EBaseUserMessages_UM_CloseCaptionPlaceholder
-		if cbs := callbacks.onCUserMessageCloseCaptionPlaceholder; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageCloseCaptionPlaceholder, callbacks.onPacketMessage[142]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageCloseCaptionPlaceholder{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2071,10 +2455,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 143: // dota.EBaseUserMessages_UM_CameraTransition
-		if cbs := callbacks.onCUserMessageCameraTransition; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageCameraTransition, callbacks.onPacketMessage[143]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageCameraTransition{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2084,10 +2470,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 144: // dota.EBaseUserMessages_UM_AudioParameter
-		if cbs := callbacks.onCUserMessageAudioParameter; cbs != nil {
+		cbs, mcbs := callbacks.onCUserMessageAudioParameter, callbacks.onPacketMessage[144]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CUserMessageAudioParameter{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2097,10 +2485,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 136: // dota.EBaseEntityMessages_EM_PlayJingle
-		if cbs := callbacks.onCEntityMessagePlayJingle; cbs != nil {
+		cbs, mcbs := callbacks.onCEntityMessagePlayJingle, callbacks.onPacketMessage[136]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CEntityMessagePlayJingle{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2110,10 +2500,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 137: // dota.EBaseEntityMessages_EM_ScreenOverlay
-		if cbs := callbacks.onCEntityMessageScreenOverlay; cbs != nil {
+		cbs, mcbs := callbacks.onCEntityMessageScreenOverlay, callbacks.onPacketMessage[137]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CEntityMessageScreenOverlay{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2123,10 +2515,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 138: // dota.EBaseEntityMessages_EM_RemoveAllDecals
-		if cbs := callbacks.onCEntityMessageRemoveAllDecals; cbs != nil {
+		cbs, mcbs := callbacks.onCEntityMessageRemoveAllDecals, callbacks.onPacketMessage[138]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CEntityMessageRemoveAllDecals{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2136,10 +2530,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 139: // dota.EBaseEntityMessages_EM_PropagateForce
-		if cbs := callbacks.onCEntityMessagePropagateForce; cbs != nil {
+		cbs, mcbs := callbacks.onCEntityMessagePropagateForce, callbacks.onPacketMessage[139]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CEntityMessagePropagateForce{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2149,10 +2545,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 140: // dota.EBaseEntityMessages_EM_DoSpark
-		if cbs := callbacks.onCEntityMessageDoSpark; cbs != nil {
+		cbs, mcbs := callbacks.onCEntityMessageDoSpark, callbacks.onPacketMessage[140]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CEntityMessageDoSpark{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2162,10 +2560,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 141: // dota.EBaseEntityMessages_EM_FixAngle
-		if cbs := callbacks.onCEntityMessageFixAngle; cbs != nil {
+		cbs, mcbs := callbacks.onCEntityMessageFixAngle, callbacks.onPacketMessage[141]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CEntityMessageFixAngle{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2175,10 +2575,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 200: // dota.EBaseGameEvents_GE_VDebugGameSessionIDEvent
-		if cbs := callbacks.onCMsgVDebugGameSessionIDEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgVDebugGameSessionIDEvent, callbacks.onPacketMessage[200]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgVDebugGameSessionIDEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2188,10 +2590,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 201: // dota.EBaseGameEvents_GE_PlaceDecalEvent
-		if cbs := callbacks.onCMsgPlaceDecalEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgPlaceDecalEvent, callbacks.onPacketMessage[201]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgPlaceDecalEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2201,10 +2605,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 202: // dota.EBaseGameEvents_GE_ClearWorldDecalsEvent
-		if cbs := callbacks.onCMsgClearWorldDecalsEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgClearWorldDecalsEvent, callbacks.onPacketMessage[202]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgClearWorldDecalsEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2214,10 +2620,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 203: // dota.EBaseGameEvents_GE_ClearEntityDecalsEvent
-		if cbs := callbacks.onCMsgClearEntityDecalsEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgClearEntityDecalsEvent, callbacks.onPacketMessage[203]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgClearEntityDecalsEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2227,10 +2635,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 204: // dota.EBaseGameEvents_GE_ClearDecalsForSkeletonInstanceEvent
-		if cbs := callbacks.onCMsgClearDecalsForSkeletonInstanceEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgClearDecalsForSkeletonInstanceEvent, callbacks.onPacketMessage[204]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgClearDecalsForSkeletonInstanceEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2240,10 +2650,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 205: // dota.EBaseGameEvents_GE_Source1LegacyGameEventList
-		if cbs := callbacks.onCMsgSource1LegacyGameEventList; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgSource1LegacyGameEventList, callbacks.onPacketMessage[205]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgSource1LegacyGameEventList{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2253,10 +2665,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 206: // dota.EBaseGameEvents_GE_Source1LegacyListenEvents
-		if cbs := callbacks.onCMsgSource1LegacyListenEvents; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgSource1LegacyListenEvents, callbacks.onPacketMessage[206]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgSource1LegacyListenEvents{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2266,10 +2680,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 207: // dota.EBaseGameEvents_GE_Source1LegacyGameEvent
-		if cbs := callbacks.onCMsgSource1LegacyGameEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgSource1LegacyGameEvent, callbacks.onPacketMessage[207]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgSource1LegacyGameEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2279,10 +2695,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 208: // dota.EBaseGameEvents_GE_SosStartSoundEvent
-		if cbs := callbacks.onCMsgSosStartSoundEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgSosStartSoundEvent, callbacks.onPacketMessage[208]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgSosStartSoundEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2292,10 +2710,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 209: // dota.EBaseGameEvents_GE_SosStopSoundEvent
-		if cbs := callbacks.onCMsgSosStopSoundEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgSosStopSoundEvent, callbacks.onPacketMessage[209]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgSosStopSoundEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2305,10 +2725,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 210: // dota.EBaseGameEvents_GE_SosSetSoundEventParams
-		if cbs := callbacks.onCMsgSosSetSoundEventParams; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgSosSetSoundEventParams, callbacks.onPacketMessage[210]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgSosSetSoundEventParams{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2318,10 +2740,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 211: // dota.EBaseGameEvents_GE_SosSetLibraryStackFields
-		if cbs := callbacks.onCMsgSosSetLibraryStackFields; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgSosSetLibraryStackFields, callbacks.onPacketMessage[211]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgSosSetLibraryStackFields{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2331,10 +2755,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 212: // dota.EBaseGameEvents_GE_SosStopSoundEventHash
-		if cbs := callbacks.onCMsgSosStopSoundEventHash; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgSosStopSoundEventHash, callbacks.onPacketMessage[212]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgSosStopSoundEventHash{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2344,10 +2770,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 465: // dota.EDotaUserMessages_DOTA_UM_AIDebugLine
-		if cbs := callbacks.onCDOTAUserMsg_AIDebugLine; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_AIDebugLine, callbacks.onPacketMessage[465]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_AIDebugLine{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2357,10 +2785,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 466: // dota.EDotaUserMessages_DOTA_UM_ChatEvent
-		if cbs := callbacks.onCDOTAUserMsg_ChatEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ChatEvent, callbacks.onPacketMessage[466]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ChatEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2370,10 +2800,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 467: // dota.EDotaUserMessages_DOTA_UM_CombatHeroPositions
-		if cbs := callbacks.onCDOTAUserMsg_CombatHeroPositions; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CombatHeroPositions, callbacks.onPacketMessage[467]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CombatHeroPositions{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2383,10 +2815,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 470: // dota.EDotaUserMessages_DOTA_UM_CombatLogShowDeath
-		if cbs := callbacks.onCDOTAUserMsg_CombatLogShowDeath; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CombatLogShowDeath, callbacks.onPacketMessage[470]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CombatLogShowDeath{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2396,10 +2830,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 471: // dota.EDotaUserMessages_DOTA_UM_CreateLinearProjectile
-		if cbs := callbacks.onCDOTAUserMsg_CreateLinearProjectile; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CreateLinearProjectile, callbacks.onPacketMessage[471]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CreateLinearProjectile{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2409,10 +2845,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 472: // dota.EDotaUserMessages_DOTA_UM_DestroyLinearProjectile
-		if cbs := callbacks.onCDOTAUserMsg_DestroyLinearProjectile; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_DestroyLinearProjectile, callbacks.onPacketMessage[472]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_DestroyLinearProjectile{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2422,10 +2860,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 473: // dota.EDotaUserMessages_DOTA_UM_DodgeTrackingProjectiles
-		if cbs := callbacks.onCDOTAUserMsg_DodgeTrackingProjectiles; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_DodgeTrackingProjectiles, callbacks.onPacketMessage[473]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_DodgeTrackingProjectiles{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2435,10 +2875,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 474: // dota.EDotaUserMessages_DOTA_UM_GlobalLightColor
-		if cbs := callbacks.onCDOTAUserMsg_GlobalLightColor; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_GlobalLightColor, callbacks.onPacketMessage[474]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_GlobalLightColor{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2448,10 +2890,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 475: // dota.EDotaUserMessages_DOTA_UM_GlobalLightDirection
-		if cbs := callbacks.onCDOTAUserMsg_GlobalLightDirection; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_GlobalLightDirection, callbacks.onPacketMessage[475]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_GlobalLightDirection{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2461,10 +2905,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 476: // dota.EDotaUserMessages_DOTA_UM_InvalidCommand
-		if cbs := callbacks.onCDOTAUserMsg_InvalidCommand; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_InvalidCommand, callbacks.onPacketMessage[476]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_InvalidCommand{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2474,10 +2920,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 477: // dota.EDotaUserMessages_DOTA_UM_LocationPing
-		if cbs := callbacks.onCDOTAUserMsg_LocationPing; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_LocationPing, callbacks.onPacketMessage[477]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_LocationPing{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2487,10 +2935,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 478: // dota.EDotaUserMessages_DOTA_UM_MapLine
-		if cbs := callbacks.onCDOTAUserMsg_MapLine; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_MapLine, callbacks.onPacketMessage[478]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_MapLine{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2500,10 +2950,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 479: // dota.EDotaUserMessages_DOTA_UM_MiniKillCamInfo
-		if cbs := callbacks.onCDOTAUserMsg_MiniKillCamInfo; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_MiniKillCamInfo, callbacks.onPacketMessage[479]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_MiniKillCamInfo{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2513,10 +2965,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 480: // dota.EDotaUserMessages_DOTA_UM_MinimapDebugPoint
-		if cbs := callbacks.onCDOTAUserMsg_MinimapDebugPoint; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_MinimapDebugPoint, callbacks.onPacketMessage[480]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_MinimapDebugPoint{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2526,10 +2980,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 481: // dota.EDotaUserMessages_DOTA_UM_MinimapEvent
-		if cbs := callbacks.onCDOTAUserMsg_MinimapEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_MinimapEvent, callbacks.onPacketMessage[481]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_MinimapEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2539,10 +2995,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 482: // dota.EDotaUserMessages_DOTA_UM_NevermoreRequiem
-		if cbs := callbacks.onCDOTAUserMsg_NevermoreRequiem; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_NevermoreRequiem, callbacks.onPacketMessage[482]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_NevermoreRequiem{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2552,10 +3010,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 483: // dota.EDotaUserMessages_DOTA_UM_OverheadEvent
-		if cbs := callbacks.onCDOTAUserMsg_OverheadEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_OverheadEvent, callbacks.onPacketMessage[483]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_OverheadEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2565,10 +3025,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 484: // dota.EDotaUserMessages_DOTA_UM_SetNextAutobuyItem
-		if cbs := callbacks.onCDOTAUserMsg_SetNextAutobuyItem; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_SetNextAutobuyItem, callbacks.onPacketMessage[484]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_SetNextAutobuyItem{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2578,10 +3040,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 485: // dota.EDotaUserMessages_DOTA_UM_SharedCooldown
-		if cbs := callbacks.onCDOTAUserMsg_SharedCooldown; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_SharedCooldown, callbacks.onPacketMessage[485]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_SharedCooldown{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2591,10 +3055,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 486: // dota.EDotaUserMessages_DOTA_UM_SpectatorPlayerClick
-		if cbs := callbacks.onCDOTAUserMsg_SpectatorPlayerClick; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_SpectatorPlayerClick, callbacks.onPacketMessage[486]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_SpectatorPlayerClick{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2604,10 +3070,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 487: // dota.EDotaUserMessages_DOTA_UM_TutorialTipInfo
-		if cbs := callbacks.onCDOTAUserMsg_TutorialTipInfo; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TutorialTipInfo, callbacks.onPacketMessage[487]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TutorialTipInfo{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2617,10 +3085,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 488: // dota.EDotaUserMessages_DOTA_UM_UnitEvent
-		if cbs := callbacks.onCDOTAUserMsg_UnitEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_UnitEvent, callbacks.onPacketMessage[488]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_UnitEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2630,10 +3100,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 489: // dota.EDotaUserMessages_DOTA_UM_ParticleManager
-		if cbs := callbacks.onCDOTAUserMsg_ParticleManager; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ParticleManager, callbacks.onPacketMessage[489]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ParticleManager{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2643,10 +3115,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 490: // dota.EDotaUserMessages_DOTA_UM_BotChat
-		if cbs := callbacks.onCDOTAUserMsg_BotChat; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_BotChat, callbacks.onPacketMessage[490]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_BotChat{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2656,10 +3130,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 491: // dota.EDotaUserMessages_DOTA_UM_HudError
-		if cbs := callbacks.onCDOTAUserMsg_HudError; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_HudError, callbacks.onPacketMessage[491]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_HudError{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2669,10 +3145,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 492: // dota.EDotaUserMessages_DOTA_UM_ItemPurchased
-		if cbs := callbacks.onCDOTAUserMsg_ItemPurchased; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ItemPurchased, callbacks.onPacketMessage[492]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ItemPurchased{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2682,10 +3160,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 493: // dota.EDotaUserMessages_DOTA_UM_Ping
-		if cbs := callbacks.onCDOTAUserMsg_Ping; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_Ping, callbacks.onPacketMessage[493]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_Ping{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2695,10 +3175,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 494: // dota.EDotaUserMessages_DOTA_UM_ItemFound
-		if cbs := callbacks.onCDOTAUserMsg_ItemFound; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ItemFound, callbacks.onPacketMessage[494]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ItemFound{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2708,10 +3190,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 496: // dota.EDotaUserMessages_DOTA_UM_SwapVerify
-		if cbs := callbacks.onCDOTAUserMsg_SwapVerify; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_SwapVerify, callbacks.onPacketMessage[496]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_SwapVerify{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2721,10 +3205,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 497: // dota.EDotaUserMessages_DOTA_UM_WorldLine
-		if cbs := callbacks.onCDOTAUserMsg_WorldLine; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_WorldLine, callbacks.onPacketMessage[497]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_WorldLine{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2734,10 +3220,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 499: // dota.EDotaUserMessages_DOTA_UM_ItemAlert
-		if cbs := callbacks.onCDOTAUserMsg_ItemAlert; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ItemAlert, callbacks.onPacketMessage[499]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ItemAlert{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2747,10 +3235,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 500: // dota.EDotaUserMessages_DOTA_UM_HalloweenDrops
-		if cbs := callbacks.onCDOTAUserMsg_HalloweenDrops; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_HalloweenDrops, callbacks.onPacketMessage[500]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_HalloweenDrops{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2760,10 +3250,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 501: // dota.EDotaUserMessages_DOTA_UM_ChatWheel
-		if cbs := callbacks.onCDOTAUserMsg_ChatWheel; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ChatWheel, callbacks.onPacketMessage[501]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ChatWheel{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2773,10 +3265,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 502: // dota.EDotaUserMessages_DOTA_UM_ReceivedXmasGift
-		if cbs := callbacks.onCDOTAUserMsg_ReceivedXmasGift; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ReceivedXmasGift, callbacks.onPacketMessage[502]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ReceivedXmasGift{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2786,10 +3280,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 503: // dota.EDotaUserMessages_DOTA_UM_UpdateSharedContent
-		if cbs := callbacks.onCDOTAUserMsg_UpdateSharedContent; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_UpdateSharedContent, callbacks.onPacketMessage[503]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_UpdateSharedContent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2799,10 +3295,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 504: // dota.EDotaUserMessages_DOTA_UM_TutorialRequestExp
-		if cbs := callbacks.onCDOTAUserMsg_TutorialRequestExp; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TutorialRequestExp, callbacks.onPacketMessage[504]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TutorialRequestExp{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2812,10 +3310,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 505: // dota.EDotaUserMessages_DOTA_UM_TutorialPingMinimap
-		if cbs := callbacks.onCDOTAUserMsg_TutorialPingMinimap; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TutorialPingMinimap, callbacks.onPacketMessage[505]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TutorialPingMinimap{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2825,10 +3325,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 506: // dota.EDotaUserMessages_DOTA_UM_GamerulesStateChanged
-		if cbs := callbacks.onCDOTAUserMsg_GamerulesStateChanged; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_GamerulesStateChanged, callbacks.onPacketMessage[506]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_GamerulesStateChanged{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2838,10 +3340,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 507: // dota.EDotaUserMessages_DOTA_UM_ShowSurvey
-		if cbs := callbacks.onCDOTAUserMsg_ShowSurvey; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ShowSurvey, callbacks.onPacketMessage[507]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ShowSurvey{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2851,10 +3355,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 508: // dota.EDotaUserMessages_DOTA_UM_TutorialFade
-		if cbs := callbacks.onCDOTAUserMsg_TutorialFade; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TutorialFade, callbacks.onPacketMessage[508]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TutorialFade{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2864,10 +3370,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 509: // dota.EDotaUserMessages_DOTA_UM_AddQuestLogEntry
-		if cbs := callbacks.onCDOTAUserMsg_AddQuestLogEntry; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_AddQuestLogEntry, callbacks.onPacketMessage[509]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_AddQuestLogEntry{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2877,10 +3385,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 510: // dota.EDotaUserMessages_DOTA_UM_SendStatPopup
-		if cbs := callbacks.onCDOTAUserMsg_SendStatPopup; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_SendStatPopup, callbacks.onPacketMessage[510]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_SendStatPopup{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2890,10 +3400,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 511: // dota.EDotaUserMessages_DOTA_UM_TutorialFinish
-		if cbs := callbacks.onCDOTAUserMsg_TutorialFinish; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TutorialFinish, callbacks.onPacketMessage[511]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TutorialFinish{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2903,10 +3415,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 512: // dota.EDotaUserMessages_DOTA_UM_SendRoshanPopup
-		if cbs := callbacks.onCDOTAUserMsg_SendRoshanPopup; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_SendRoshanPopup, callbacks.onPacketMessage[512]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_SendRoshanPopup{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2916,10 +3430,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 513: // dota.EDotaUserMessages_DOTA_UM_SendGenericToolTip
-		if cbs := callbacks.onCDOTAUserMsg_SendGenericToolTip; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_SendGenericToolTip, callbacks.onPacketMessage[513]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_SendGenericToolTip{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2929,10 +3445,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 514: // dota.EDotaUserMessages_DOTA_UM_SendFinalGold
-		if cbs := callbacks.onCDOTAUserMsg_SendFinalGold; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_SendFinalGold, callbacks.onPacketMessage[514]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_SendFinalGold{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2942,10 +3460,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 515: // dota.EDotaUserMessages_DOTA_UM_CustomMsg
-		if cbs := callbacks.onCDOTAUserMsg_CustomMsg; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CustomMsg, callbacks.onPacketMessage[515]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CustomMsg{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2955,10 +3475,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 516: // dota.EDotaUserMessages_DOTA_UM_CoachHUDPing
-		if cbs := callbacks.onCDOTAUserMsg_CoachHUDPing; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CoachHUDPing, callbacks.onPacketMessage[516]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CoachHUDPing{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2968,10 +3490,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 517: // dota.EDotaUserMessages_DOTA_UM_ClientLoadGridNav
-		if cbs := callbacks.onCDOTAUserMsg_ClientLoadGridNav; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ClientLoadGridNav, callbacks.onPacketMessage[517]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ClientLoadGridNav{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2981,10 +3505,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 518: // dota.EDotaUserMessages_DOTA_UM_TE_Projectile
-		if cbs := callbacks.onCDOTAUserMsg_TE_Projectile; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TE_Projectile, callbacks.onPacketMessage[518]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TE_Projectile{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -2994,10 +3520,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 519: // dota.EDotaUserMessages_DOTA_UM_TE_ProjectileLoc
-		if cbs := callbacks.onCDOTAUserMsg_TE_ProjectileLoc; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TE_ProjectileLoc, callbacks.onPacketMessage[519]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TE_ProjectileLoc{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3007,10 +3535,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 520: // dota.EDotaUserMessages_DOTA_UM_TE_DotaBloodImpact
-		if cbs := callbacks.onCDOTAUserMsg_TE_DotaBloodImpact; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TE_DotaBloodImpact, callbacks.onPacketMessage[520]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TE_DotaBloodImpact{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3020,10 +3550,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 521: // dota.EDotaUserMessages_DOTA_UM_TE_UnitAnimation
-		if cbs := callbacks.onCDOTAUserMsg_TE_UnitAnimation; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TE_UnitAnimation, callbacks.onPacketMessage[521]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TE_UnitAnimation{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3033,10 +3565,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 522: // dota.EDotaUserMessages_DOTA_UM_TE_UnitAnimationEnd
-		if cbs := callbacks.onCDOTAUserMsg_TE_UnitAnimationEnd; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TE_UnitAnimationEnd, callbacks.onPacketMessage[522]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TE_UnitAnimationEnd{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3046,10 +3580,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 523: // dota.EDotaUserMessages_DOTA_UM_AbilityPing
-		if cbs := callbacks.onCDOTAUserMsg_AbilityPing; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_AbilityPing, callbacks.onPacketMessage[523]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_AbilityPing{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3059,10 +3595,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 524: // dota.EDotaUserMessages_DOTA_UM_ShowGenericPopup
-		if cbs := callbacks.onCDOTAUserMsg_ShowGenericPopup; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ShowGenericPopup, callbacks.onPacketMessage[524]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ShowGenericPopup{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3072,10 +3610,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 525: // dota.EDotaUserMessages_DOTA_UM_VoteStart
-		if cbs := callbacks.onCDOTAUserMsg_VoteStart; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_VoteStart, callbacks.onPacketMessage[525]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_VoteStart{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3085,10 +3625,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 526: // dota.EDotaUserMessages_DOTA_UM_VoteUpdate
-		if cbs := callbacks.onCDOTAUserMsg_VoteUpdate; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_VoteUpdate, callbacks.onPacketMessage[526]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_VoteUpdate{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3098,10 +3640,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 527: // dota.EDotaUserMessages_DOTA_UM_VoteEnd
-		if cbs := callbacks.onCDOTAUserMsg_VoteEnd; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_VoteEnd, callbacks.onPacketMessage[527]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_VoteEnd{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3111,10 +3655,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 528: // dota.EDotaUserMessages_DOTA_UM_BoosterState
-		if cbs := callbacks.onCDOTAUserMsg_BoosterState; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_BoosterState, callbacks.onPacketMessage[528]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_BoosterState{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3124,10 +3670,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 529: // dota.EDotaUserMessages_DOTA_UM_WillPurchaseAlert
-		if cbs := callbacks.onCDOTAUserMsg_WillPurchaseAlert; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_WillPurchaseAlert, callbacks.onPacketMessage[529]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_WillPurchaseAlert{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3137,10 +3685,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 530: // dota.EDotaUserMessages_DOTA_UM_TutorialMinimapPosition
-		if cbs := callbacks.onCDOTAUserMsg_TutorialMinimapPosition; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_TutorialMinimapPosition, callbacks.onPacketMessage[530]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_TutorialMinimapPosition{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3150,10 +3700,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 531: // dota.EDotaUserMessages_DOTA_UM_PlayerMMR
-		if cbs := callbacks.onCDOTAUserMsg_PlayerMMR; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_PlayerMMR, callbacks.onPacketMessage[531]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_PlayerMMR{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3163,10 +3715,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 532: // dota.EDotaUserMessages_DOTA_UM_AbilitySteal
-		if cbs := callbacks.onCDOTAUserMsg_AbilitySteal; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_AbilitySteal, callbacks.onPacketMessage[532]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_AbilitySteal{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3176,10 +3730,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 533: // dota.EDotaUserMessages_DOTA_UM_CourierKilledAlert
-		if cbs := callbacks.onCDOTAUserMsg_CourierKilledAlert; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CourierKilledAlert, callbacks.onPacketMessage[533]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CourierKilledAlert{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3189,10 +3745,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 534: // dota.EDotaUserMessages_DOTA_UM_EnemyItemAlert
-		if cbs := callbacks.onCDOTAUserMsg_EnemyItemAlert; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_EnemyItemAlert, callbacks.onPacketMessage[534]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_EnemyItemAlert{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3202,10 +3760,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 535: // dota.EDotaUserMessages_DOTA_UM_StatsMatchDetails
-		if cbs := callbacks.onCDOTAUserMsg_StatsMatchDetails; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_StatsMatchDetails, callbacks.onPacketMessage[535]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_StatsMatchDetails{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3215,10 +3775,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 536: // dota.EDotaUserMessages_DOTA_UM_MiniTaunt
-		if cbs := callbacks.onCDOTAUserMsg_MiniTaunt; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_MiniTaunt, callbacks.onPacketMessage[536]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_MiniTaunt{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3228,10 +3790,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 537: // dota.EDotaUserMessages_DOTA_UM_BuyBackStateAlert
-		if cbs := callbacks.onCDOTAUserMsg_BuyBackStateAlert; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_BuyBackStateAlert, callbacks.onPacketMessage[537]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_BuyBackStateAlert{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3241,10 +3805,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 538: // dota.EDotaUserMessages_DOTA_UM_SpeechBubble
-		if cbs := callbacks.onCDOTAUserMsg_SpeechBubble; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_SpeechBubble, callbacks.onPacketMessage[538]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_SpeechBubble{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3254,10 +3820,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 539: // dota.EDotaUserMessages_DOTA_UM_CustomHeaderMessage
-		if cbs := callbacks.onCDOTAUserMsg_CustomHeaderMessage; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CustomHeaderMessage, callbacks.onPacketMessage[539]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CustomHeaderMessage{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3267,10 +3835,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 540: // dota.EDotaUserMessages_DOTA_UM_QuickBuyAlert
-		if cbs := callbacks.onCDOTAUserMsg_QuickBuyAlert; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_QuickBuyAlert, callbacks.onPacketMessage[540]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_QuickBuyAlert{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3280,10 +3850,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 542: // dota.EDotaUserMessages_DOTA_UM_PredictionResult
-		if cbs := callbacks.onCDOTAUserMsg_PredictionResult; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_PredictionResult, callbacks.onPacketMessage[542]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_PredictionResult{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3293,10 +3865,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 543: // dota.EDotaUserMessages_DOTA_UM_ModifierAlert
-		if cbs := callbacks.onCDOTAUserMsg_ModifierAlert; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ModifierAlert, callbacks.onPacketMessage[543]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ModifierAlert{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3306,10 +3880,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 544: // dota.EDotaUserMessages_DOTA_UM_HPManaAlert
-		if cbs := callbacks.onCDOTAUserMsg_HPManaAlert; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_HPManaAlert, callbacks.onPacketMessage[544]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_HPManaAlert{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3319,10 +3895,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 545: // dota.EDotaUserMessages_DOTA_UM_GlyphAlert
-		if cbs := callbacks.onCDOTAUserMsg_GlyphAlert; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_GlyphAlert, callbacks.onPacketMessage[545]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_GlyphAlert{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3332,10 +3910,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 546: // dota.EDotaUserMessages_DOTA_UM_BeastChat
-		if cbs := callbacks.onCDOTAUserMsg_BeastChat; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_BeastChat, callbacks.onPacketMessage[546]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_BeastChat{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3345,10 +3925,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 547: // dota.EDotaUserMessages_DOTA_UM_SpectatorPlayerUnitOrders
-		if cbs := callbacks.onCDOTAUserMsg_SpectatorPlayerUnitOrders; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_SpectatorPlayerUnitOrders, callbacks.onPacketMessage[547]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_SpectatorPlayerUnitOrders{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3358,10 +3940,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 548: // dota.EDotaUserMessages_DOTA_UM_CustomHudElement_Create
-		if cbs := callbacks.onCDOTAUserMsg_CustomHudElement_Create; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CustomHudElement_Create, callbacks.onPacketMessage[548]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CustomHudElement_Create{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3371,10 +3955,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 549: // dota.EDotaUserMessages_DOTA_UM_CustomHudElement_Modify
-		if cbs := callbacks.onCDOTAUserMsg_CustomHudElement_Modify; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CustomHudElement_Modify, callbacks.onPacketMessage[549]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CustomHudElement_Modify{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3384,10 +3970,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 550: // dota.EDotaUserMessages_DOTA_UM_CustomHudElement_Destroy
-		if cbs := callbacks.onCDOTAUserMsg_CustomHudElement_Destroy; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CustomHudElement_Destroy, callbacks.onPacketMessage[550]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CustomHudElement_Destroy{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3397,10 +3985,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 551: // dota.EDotaUserMessages_DOTA_UM_CompendiumState
-		if cbs := callbacks.onCDOTAUserMsg_CompendiumState; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_CompendiumState, callbacks.onPacketMessage[551]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_CompendiumState{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3410,10 +4000,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 552: // dota.EDotaUserMessages_DOTA_UM_ProjectionAbility
-		if cbs := callbacks.onCDOTAUserMsg_ProjectionAbility; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ProjectionAbility, callbacks.onPacketMessage[552]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ProjectionAbility{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3423,10 +4015,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 553: // dota.EDotaUserMessages_DOTA_UM_ProjectionEvent
-		if cbs := callbacks.onCDOTAUserMsg_ProjectionEvent; cbs != nil {
+		cbs, mcbs := callbacks.onCDOTAUserMsg_ProjectionEvent, callbacks.onPacketMessage[553]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CDOTAUserMsg_ProjectionEvent{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3436,10 +4030,12 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	case 554: // dota.EDotaUserMessages_DOTA_UM_CombatLogDataHLTV
-		if cbs := callbacks.onCMsgDOTACombatLogEntry; cbs != nil {
+		cbs, mcbs := callbacks.onCMsgDOTACombatLogEntry, callbacks.onPacketMessage[554]
+		if cbs != nil || mcbs != nil || callbacks.onMessage != nil {
 			msg := &dota.CMsgDOTACombatLogEntry{}
 			if err := proto.Unmarshal(raw, msg); err != nil {
 				return err
@@ -3449,10 +4045,372 @@ func (p *Parser) CallByPacketType(t int32, raw []byte) error {
 					return err
 				}
 			}
+			return p.callMessage(callbacks, mcbs, msg)
 		}
 		return nil
 	}
-	return fmt.Errorf("no type found: %d", t)
+	return p.callUnknownMessage(callbacks.onUnknownPacket, t, raw)
+}
+
+// Determines whether or not any callbacks are registered for an inner packet
+// type, so that packets nobody is listening to can be skipped unread.
+func (c *Callbacks) hasPacketCallbacks(t int32) bool {
+	if c.onMessage != nil {
+		return true
+	}
+	switch t {
+	case 0:
+		return c.onCNETMsg_NOP != nil || c.onPacketMessage[0] != nil
+	case 1:
+		return c.onCNETMsg_Disconnect != nil || c.onPacketMessage[1] != nil
+	case 3:
+		return c.onCNETMsg_SplitScreenUser != nil || c.onPacketMessage[3] != nil
+	case 4:
+		return c.onCNETMsg_Tick != nil || c.onPacketMessage[4] != nil
+	case 5:
+		return c.onCNETMsg_StringCmd != nil || c.onPacketMessage[5] != nil
+	case 6:
+		return c.onCNETMsg_SetConVar != nil || c.onPacketMessage[6] != nil
+	case 7:
+		return c.onCNETMsg_SignonState != nil || c.onPacketMessage[7] != nil
+	case 8:
+		return c.onCNETMsg_SpawnGroup_Load != nil || c.onPacketMessage[8] != nil
+	case 9:
+		return c.onCNETMsg_SpawnGroup_ManifestUpdate != nil || c.onPacketMessage[9] != nil
+	case 11:
+		return c.onCNETMsg_SpawnGroup_SetCreationTick != nil || c.onPacketMessage[11] != nil
+	case 12:
+		return c.onCNETMsg_SpawnGroup_Unload != nil || c.onPacketMessage[12] != nil
+	case 13:
+		return c.onCNETMsg_SpawnGroup_LoadCompleted != nil || c.onPacketMessage[13] != nil
+	case 40:
+		return c.onCSVCMsg_ServerInfo != nil || c.onPacketMessage[40] != nil
+	case 41:
+		return c.onCSVCMsg_FlattenedSerializer != nil || c.onPacketMessage[41] != nil
+	case 42:
+		return c.onCSVCMsg_ClassInfo != nil || c.onPacketMessage[42] != nil
+	case 43:
+		return c.onCSVCMsg_SetPause != nil || c.onPacketMessage[43] != nil
+	case 44:
+		return c.onCSVCMsg_CreateStringTable != nil || c.onPacketMessage[44] != nil
+	case 45:
+		return c.onCSVCMsg_UpdateStringTable != nil || c.onPacketMessage[45] != nil
+	case 46:
+		return c.onCSVCMsg_VoiceInit != nil || c.onPacketMessage[46] != nil
+	case 47:
+		return c.onCSVCMsg_VoiceData != nil || c.onPacketMessage[47] != nil
+	case 48:
+		return c.onCSVCMsg_Print != nil || c.onPacketMessage[48] != nil
+	case 49:
+		return c.onCSVCMsg_Sounds != nil || c.onPacketMessage[49] != nil
+	case 50:
+		return c.onCSVCMsg_SetView != nil || c.onPacketMessage[50] != nil
+	case 51:
+		return c.onCSVCMsg_ClearAllStringTables != nil || c.onPacketMessage[51] != nil
+	case 52:
+		return c.onCSVCMsg_CmdKeyValues != nil || c.onPacketMessage[52] != nil
+	case 53:
+		return c.onCSVCMsg_BSPDecal != nil || c.onPacketMessage[53] != nil
+	case 54:
+		return c.onCSVCMsg_SplitScreen != nil || c.onPacketMessage[54] != nil
+	case 55:
+		return c.onCSVCMsg_PacketEntities != nil || c.onPacketMessage[55] != nil
+	case 56:
+		return c.onCSVCMsg_Prefetch != nil || c.onPacketMessage[56] != nil
+	case 57:
+		return c.onCSVCMsg_Menu != nil || c.onPacketMessage[57] != nil
+	case 58:
+		return c.onCSVCMsg_GetCvarValue != nil || c.onPacketMessage[58] != nil
+	case 59:
+		return c.onCSVCMsg_StopSound != nil || c.onPacketMessage[59] != nil
+	case 60:
+		return c.onCSVCMsg_PeerList != nil || c.onPacketMessage[60] != nil
+	case 61:
+		return c.onCSVCMsg_PacketReliable != nil || c.onPacketMessage[61] != nil
+	case 62:
+		return c.onCSVCMsg_HLTVStatus != nil || c.onPacketMessage[62] != nil
+	case 70:
+		return c.onCSVCMsg_FullFrameSplit != nil || c.onPacketMessage[70] != nil
+	case 101:
+		return c.onCUserMessageAchievementEvent != nil || c.onPacketMessage[101] != nil
+	case 102:
+		return c.onCUserMessageCloseCaption != nil || c.onPacketMessage[102] != nil
+	case 103:
+		return c.onCUserMessageCloseCaptionDirect != nil || c.onPacketMessage[103] != nil
+	case 104:
+		return c.onCUserMessageCurrentTimescale != nil || c.onPacketMessage[104] != nil
+	case 105:
+		return c.onCUserMessageDesiredTimescale != nil || c.onPacketMessage[105] != nil
+	case 106:
+		return c.onCUserMessageFade != nil || c.onPacketMessage[106] != nil
+	case 107:
+		return c.onCUserMessageGameTitle != nil || c.onPacketMessage[107] != nil
+	case 109:
+		return c.onCUserMessageHintText != nil || c.onPacketMessage[109] != nil
+	case 110:
+		return c.onCUserMessageHudMsg != nil || c.onPacketMessage[110] != nil
+	case 111:
+		return c.onCUserMessageHudText != nil || c.onPacketMessage[111] != nil
+	case 112:
+		return c.onCUserMessageKeyHintText != nil || c.onPacketMessage[112] != nil
+	case 113:
+		return c.onCUserMessageColoredText != nil || c.onPacketMessage[113] != nil
+	case 114:
+		return c.onCUserMessageRequestState != nil || c.onPacketMessage[114] != nil
+	case 115:
+		return c.onCUserMessageResetHUD != nil || c.onPacketMessage[115] != nil
+	case 116:
+		return c.onCUserMessageRumble != nil || c.onPacketMessage[116] != nil
+	case 117:
+		return c.onCUserMessageSayText != nil || c.onPacketMessage[117] != nil
+	case 118:
+		return c.onCUserMessageSayText2 != nil || c.onPacketMessage[118] != nil
+	case 119:
+		return c.onCUserMessageSayTextChannel != nil || c.onPacketMessage[119] != nil
+	case 120:
+		return c.onCUserMessageShake != nil || c.onPacketMessage[120] != nil
+	case 121:
+		return c.onCUserMessageShakeDir != nil || c.onPacketMessage[121] != nil
+	case 124:
+		return c.onCUserMessageTextMsg != nil || c.onPacketMessage[124] != nil
+	case 125:
+		return c.onCUserMessageScreenTilt != nil || c.onPacketMessage[125] != nil
+	case 126:
+		return c.onCUserMessageTrain != nil || c.onPacketMessage[126] != nil
+	case 127:
+		return c.onCUserMessageVGUIMenu != nil || c.onPacketMessage[127] != nil
+	case 128:
+		return c.onCUserMessageVoiceMask != nil || c.onPacketMessage[128] != nil
+	case 129:
+		return c.onCUserMessageVoiceSubtitle != nil || c.onPacketMessage[129] != nil
+	case 130:
+		return c.onCUserMessageSendAudio != nil || c.onPacketMessage[130] != nil
+	case 131:
+		return c.onCUserMessageItemPickup != nil || c.onPacketMessage[131] != nil
+	case 132:
+		return c.onCUserMessageAmmoDenied != nil || c.onPacketMessage[132] != nil
+	case 133:
+		return c.onCUserMessageCrosshairAngle != nil || c.onPacketMessage[133] != nil
+	case 134:
+		return c.onCUserMessageShowMenu != nil || c.onPacketMessage[134] != nil
+	case 135:
+		return c.onCUserMessageCreditsMsg != nil || c.onPacketMessage[135] != nil
+	case 142:
+		return c.onCUserMessageCloseCaptionPlaceholder != nil || c.onPacketMessage[142] != nil
+	case 143:
+		return c.onCUserMessageCameraTransition != nil || c.onPacketMessage[143] != nil
+	case 144:
+		return c.onCUserMessageAudioParameter != nil || c.onPacketMessage[144] != nil
+	case 136:
+		return c.onCEntityMessagePlayJingle != nil || c.onPacketMessage[136] != nil
+	case 137:
+		return c.onCEntityMessageScreenOverlay != nil || c.onPacketMessage[137] != nil
+	case 138:
+		return c.onCEntityMessageRemoveAllDecals != nil || c.onPacketMessage[138] != nil
+	case 139:
+		return c.onCEntityMessagePropagateForce != nil || c.onPacketMessage[139] != nil
+	case 140:
+		return c.onCEntityMessageDoSpark != nil || c.onPacketMessage[140] != nil
+	case 141:
+		return c.onCEntityMessageFixAngle != nil || c.onPacketMessage[141] != nil
+	case 200:
+		return c.onCMsgVDebugGameSessionIDEvent != nil || c.onPacketMessage[200] != nil
+	case 201:
+		return c.onCMsgPlaceDecalEvent != nil || c.onPacketMessage[201] != nil
+	case 202:
+		return c.onCMsgClearWorldDecalsEvent != nil || c.onPacketMessage[202] != nil
+	case 203:
+		return c.onCMsgClearEntityDecalsEvent != nil || c.onPacketMessage[203] != nil
+	case 204:
+		return c.onCMsgClearDecalsForSkeletonInstanceEvent != nil || c.onPacketMessage[204] != nil
+	case 205:
+		return c.onCMsgSource1LegacyGameEventList != nil || c.onPacketMessage[205] != nil
+	case 206:
+		return c.onCMsgSource1LegacyListenEvents != nil || c.onPacketMessage[206] != nil
+	case 207:
+		return c.onCMsgSource1LegacyGameEvent != nil || c.onPacketMessage[207] != nil
+	case 208:
+		return c.onCMsgSosStartSoundEvent != nil || c.onPacketMessage[208] != nil
+	case 209:
+		return c.onCMsgSosStopSoundEvent != nil || c.onPacketMessage[209] != nil
+	case 210:
+		return c.onCMsgSosSetSoundEventParams != nil || c.onPacketMessage[210] != nil
+	case 211:
+		return c.onCMsgSosSetLibraryStackFields != nil || c.onPacketMessage[211] != nil
+	case 212:
+		return c.onCMsgSosStopSoundEventHash != nil || c.onPacketMessage[212] != nil
+	case 465:
+		return c.onCDOTAUserMsg_AIDebugLine != nil || c.onPacketMessage[465] != nil
+	case 466:
+		return c.onCDOTAUserMsg_ChatEvent != nil || c.onPacketMessage[466] != nil
+	case 467:
+		return c.onCDOTAUserMsg_CombatHeroPositions != nil || c.onPacketMessage[467] != nil
+	case 470:
+		return c.onCDOTAUserMsg_CombatLogShowDeath != nil || c.onPacketMessage[470] != nil
+	case 471:
+		return c.onCDOTAUserMsg_CreateLinearProjectile != nil || c.onPacketMessage[471] != nil
+	case 472:
+		return c.onCDOTAUserMsg_DestroyLinearProjectile != nil || c.onPacketMessage[472] != nil
+	case 473:
+		return c.onCDOTAUserMsg_DodgeTrackingProjectiles != nil || c.onPacketMessage[473] != nil
+	case 474:
+		return c.onCDOTAUserMsg_GlobalLightColor != nil || c.onPacketMessage[474] != nil
+	case 475:
+		return c.onCDOTAUserMsg_GlobalLightDirection != nil || c.onPacketMessage[475] != nil
+	case 476:
+		return c.onCDOTAUserMsg_InvalidCommand != nil || c.onPacketMessage[476] != nil
+	case 477:
+		return c.onCDOTAUserMsg_LocationPing != nil || c.onPacketMessage[477] != nil
+	case 478:
+		return c.onCDOTAUserMsg_MapLine != nil || c.onPacketMessage[478] != nil
+	case 479:
+		return c.onCDOTAUserMsg_MiniKillCamInfo != nil || c.onPacketMessage[479] != nil
+	case 480:
+		return c.onCDOTAUserMsg_MinimapDebugPoint != nil || c.onPacketMessage[480] != nil
+	case 481:
+		return c.onCDOTAUserMsg_MinimapEvent != nil || c.onPacketMessage[481] != nil
+	case 482:
+		return c.onCDOTAUserMsg_NevermoreRequiem != nil || c.onPacketMessage[482] != nil
+	case 483:
+		return c.onCDOTAUserMsg_OverheadEvent != nil || c.onPacketMessage[483] != nil
+	case 484:
+		return c.onCDOTAUserMsg_SetNextAutobuyItem != nil || c.onPacketMessage[484] != nil
+	case 485:
+		return c.onCDOTAUserMsg_SharedCooldown != nil || c.onPacketMessage[485] != nil
+	case 486:
+		return c.onCDOTAUserMsg_SpectatorPlayerClick != nil || c.onPacketMessage[486] != nil
+	case 487:
+		return c.onCDOTAUserMsg_TutorialTipInfo != nil || c.onPacketMessage[487] != nil
+	case 488:
+		return c.onCDOTAUserMsg_UnitEvent != nil || c.onPacketMessage[488] != nil
+	case 489:
+		return c.onCDOTAUserMsg_ParticleManager != nil || c.onPacketMessage[489] != nil
+	case 490:
+		return c.onCDOTAUserMsg_BotChat != nil || c.onPacketMessage[490] != nil
+	case 491:
+		return c.onCDOTAUserMsg_HudError != nil || c.onPacketMessage[491] != nil
+	case 492:
+		return c.onCDOTAUserMsg_ItemPurchased != nil || c.onPacketMessage[492] != nil
+	case 493:
+		return c.onCDOTAUserMsg_Ping != nil || c.onPacketMessage[493] != nil
+	case 494:
+		return c.onCDOTAUserMsg_ItemFound != nil || c.onPacketMessage[494] != nil
+	case 496:
+		return c.onCDOTAUserMsg_SwapVerify != nil || c.onPacketMessage[496] != nil
+	case 497:
+		return c.onCDOTAUserMsg_WorldLine != nil || c.onPacketMessage[497] != nil
+	case 499:
+		return c.onCDOTAUserMsg_ItemAlert != nil || c.onPacketMessage[499] != nil
+	case 500:
+		return c.onCDOTAUserMsg_HalloweenDrops != nil || c.onPacketMessage[500] != nil
+	case 501:
+		return c.onCDOTAUserMsg_ChatWheel != nil || c.onPacketMessage[501] != nil
+	case 502:
+		return c.onCDOTAUserMsg_ReceivedXmasGift != nil || c.onPacketMessage[502] != nil
+	case 503:
+		return c.onCDOTAUserMsg_UpdateSharedContent != nil || c.onPacketMessage[503] != nil
+	case 504:
+		return c.onCDOTAUserMsg_TutorialRequestExp != nil || c.onPacketMessage[504] != nil
+	case 505:
+		return c.onCDOTAUserMsg_TutorialPingMinimap != nil || c.onPacketMessage[505] != nil
+	case 506:
+		return c.onCDOTAUserMsg_GamerulesStateChanged != nil || c.onPacketMessage[506] != nil
+	case 507:
+		return c.onCDOTAUserMsg_ShowSurvey != nil || c.onPacketMessage[507] != nil
+	case 508:
+		return c.onCDOTAUserMsg_TutorialFade != nil || c.onPacketMessage[508] != nil
+	case 509:
+		return c.onCDOTAUserMsg_AddQuestLogEntry != nil || c.onPacketMessage[509] != nil
+	case 510:
+		return c.onCDOTAUserMsg_SendStatPopup != nil || c.onPacketMessage[510] != nil
+	case 511:
+		return c.onCDOTAUserMsg_TutorialFinish != nil || c.onPacketMessage[511] != nil
+	case 512:
+		return c.onCDOTAUserMsg_SendRoshanPopup != nil || c.onPacketMessage[512] != nil
+	case 513:
+		return c.onCDOTAUserMsg_SendGenericToolTip != nil || c.onPacketMessage[513] != nil
+	case 514:
+		return c.onCDOTAUserMsg_SendFinalGold != nil || c.onPacketMessage[514] != nil
+	case 515:
+		return c.onCDOTAUserMsg_CustomMsg != nil || c.onPacketMessage[515] != nil
+	case 516:
+		return c.onCDOTAUserMsg_CoachHUDPing != nil || c.onPacketMessage[516] != nil
+	case 517:
+		return c.onCDOTAUserMsg_ClientLoadGridNav != nil || c.onPacketMessage[517] != nil
+	case 518:
+		return c.onCDOTAUserMsg_TE_Projectile != nil || c.onPacketMessage[518] != nil
+	case 519:
+		return c.onCDOTAUserMsg_TE_ProjectileLoc != nil || c.onPacketMessage[519] != nil
+	case 520:
+		return c.onCDOTAUserMsg_TE_DotaBloodImpact != nil || c.onPacketMessage[520] != nil
+	case 521:
+		return c.onCDOTAUserMsg_TE_UnitAnimation != nil || c.onPacketMessage[521] != nil
+	case 522:
+		return c.onCDOTAUserMsg_TE_UnitAnimationEnd != nil || c.onPacketMessage[522] != nil
+	case 523:
+		return c.onCDOTAUserMsg_AbilityPing != nil || c.onPacketMessage[523] != nil
+	case 524:
+		return c.onCDOTAUserMsg_ShowGenericPopup != nil || c.onPacketMessage[524] != nil
+	case 525:
+		return c.onCDOTAUserMsg_VoteStart != nil || c.onPacketMessage[525] != nil
+	case 526:
+		return c.onCDOTAUserMsg_VoteUpdate != nil || c.onPacketMessage[526] != nil
+	case 527:
+		return c.onCDOTAUserMsg_VoteEnd != nil || c.onPacketMessage[527] != nil
+	case 528:
+		return c.onCDOTAUserMsg_BoosterState != nil || c.onPacketMessage[528] != nil
+	case 529:
+		return c.onCDOTAUserMsg_WillPurchaseAlert != nil || c.onPacketMessage[529] != nil
+	case 530:
+		return c.onCDOTAUserMsg_TutorialMinimapPosition != nil || c.onPacketMessage[530] != nil
+	case 531:
+		return c.onCDOTAUserMsg_PlayerMMR != nil || c.onPacketMessage[531] != nil
+	case 532:
+		return c.onCDOTAUserMsg_AbilitySteal != nil || c.onPacketMessage[532] != nil
+	case 533:
+		return c.onCDOTAUserMsg_CourierKilledAlert != nil || c.onPacketMessage[533] != nil
+	case 534:
+		return c.onCDOTAUserMsg_EnemyItemAlert != nil || c.onPacketMessage[534] != nil
+	case 535:
+		return c.onCDOTAUserMsg_StatsMatchDetails != nil || c.onPacketMessage[535] != nil
+	case 536:
+		return c.onCDOTAUserMsg_MiniTaunt != nil || c.onPacketMessage[536] != nil
+	case 537:
+		return c.onCDOTAUserMsg_BuyBackStateAlert != nil || c.onPacketMessage[537] != nil
+	case 538:
+		return c.onCDOTAUserMsg_SpeechBubble != nil || c.onPacketMessage[538] != nil
+	case 539:
+		return c.onCDOTAUserMsg_CustomHeaderMessage != nil || c.onPacketMessage[539] != nil
+	case 540:
+		return c.onCDOTAUserMsg_QuickBuyAlert != nil || c.onPacketMessage[540] != nil
+	case 542:
+		return c.onCDOTAUserMsg_PredictionResult != nil || c.onPacketMessage[542] != nil
+	case 543:
+		return c.onCDOTAUserMsg_ModifierAlert != nil || c.onPacketMessage[543] != nil
+	case 544:
+		return c.onCDOTAUserMsg_HPManaAlert != nil || c.onPacketMessage[544] != nil
+	case 545:
+		return c.onCDOTAUserMsg_GlyphAlert != nil || c.onPacketMessage[545] != nil
+	case 546:
+		return c.onCDOTAUserMsg_BeastChat != nil || c.onPacketMessage[546] != nil
+	case 547:
+		return c.onCDOTAUserMsg_SpectatorPlayerUnitOrders != nil || c.onPacketMessage[547] != nil
+	case 548:
+		return c.onCDOTAUserMsg_CustomHudElement_Create != nil || c.onPacketMessage[548] != nil
+	case 549:
+		return c.onCDOTAUserMsg_CustomHudElement_Modify != nil || c.onPacketMessage[549] != nil
+	case 550:
+		return c.onCDOTAUserMsg_CustomHudElement_Destroy != nil || c.onPacketMessage[550] != nil
+	case 551:
+		return c.onCDOTAUserMsg_CompendiumState != nil || c.onPacketMessage[551] != nil
+	case 552:
+		return c.onCDOTAUserMsg_ProjectionAbility != nil || c.onPacketMessage[552] != nil
+	case 553:
+		return c.onCDOTAUserMsg_ProjectionEvent != nil || c.onPacketMessage[553] != nil
+	case 554:
+		return c.onCMsgDOTACombatLogEntry != nil || c.onPacketMessage[554] != nil
+	}
+	return c.onUnknownPacket != nil
 }
 
 func (c *Callbacks) OnAny(all func(interface{}) error) {
@@ -1,6 +1,9 @@
 package manta
 
 import (
+	"reflect"
+	"sort"
+
 	"github.com/dotabuff/manta/dota"
 )
 
@@ -13,19 +16,30 @@ type PacketEntity struct {
 	Properties    *Properties
 	Serial        int32
 
+	// Whether the entity is in the PVS. Entities that leave the PVS keep
+	// their last known state until they enter it again or are deleted.
+	Active bool
+
 	flatTbl *dt
+	parser  *Parser
 }
 
 // Represents a Packet Entity Event Type
 type EntityEventType int
 
-// Possible Packet Entity Event Types
+// Possible Packet Entity Event Types. An entity is created, then updated any
+// number of times until it's deleted. In between, it may leave the PVS, after
+// which it's inactive and isn't updated until it enters again. Entering is
+// reported instead of the update that brings it back, carrying any changed
+// properties. Deleted entities are removed from Parser.PacketEntities, while
+// inactive ones remain.
 const (
 	EntityEventType_None   = EntityEventType(0)
 	EntityEventType_Create = EntityEventType(1)
 	EntityEventType_Update = EntityEventType(2)
 	EntityEventType_Delete = EntityEventType(3)
 	EntityEventType_Leave  = EntityEventType(4)
+	EntityEventType_Enter  = EntityEventType(5)
 )
 
 // Represents a packet entity update that happened this tick.
@@ -43,6 +57,50 @@ func (pe *PacketEntity) Fetch(key string) (interface{}, bool) {
 	return pe.ClassBaseline.Fetch(key)
 }
 
+// Returns the effective properties of the entity, being the baseline
+// properties overlaid with those set on the entity. The map is a copy, but the
+// values are shared with the entity.
+func (pe *PacketEntity) AllProperties() map[string]interface{} {
+	all := make(map[string]interface{})
+	for _, props := range []*Properties{pe.ClassBaseline, pe.Properties} {
+		if props == nil {
+			continue
+		}
+		for k, v := range props.KV {
+			all[k] = v
+		}
+	}
+	return all
+}
+
+// Returns the keys of the effective properties of the entity in serializer
+// order. Any keys not in the serializer come last, in lexical order.
+func (pe *PacketEntity) Keys() []string {
+	keys := make([]string, 0)
+	for k := range pe.AllProperties() {
+		keys = append(keys, k)
+	}
+
+	order := map[string]int{}
+	if pe.flatTbl != nil {
+		order = pe.flatTbl.propertyOrder()
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		oi, iok := order[keys[i]]
+		oj, jok := order[keys[j]]
+		switch {
+		case iok && jok:
+			return oi < oj
+		case iok != jok:
+			return iok
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
 // Fetches a bool
 func (pe *PacketEntity) FetchBool(key string) (bool, bool) {
 	if v, ok := pe.Properties.FetchBool(key); ok {
@@ -108,13 +166,31 @@ func (p *Parser) onCSVCMsg_PacketEntities(m *dota.CSVCMsg_PacketEntities) error
 
 	_debugfl(5, "pTick=%d isDelta=%v deltaFrom=%d updatedEntries=%d maxEntries=%d baseline=%d updateBaseline=%v", p.Tick, m.GetIsDelta(), m.GetDeltaFrom(), m.GetUpdatedEntries(), m.GetMaxEntries(), m.GetBaseline(), m.GetUpdateBaseline())
 
-	// Skip processing full updates after the first. We'll process deltas instead.
-	if !m.GetIsDelta() && p.packetEntityFullPackets > 0 {
-		return nil
+	// Full updates after the first are read into a new set of entities, which
+	// is then used to resync the entities built up from deltas.
+	entities := p.PacketEntities
+	resync := !m.GetIsDelta() && p.packetEntityFullPackets > 0
+	if resync {
+		entities = make(map[int32]*PacketEntity)
+	}
+
+	// Entity baselines only make sense relative to the first full update.
+	if !m.GetIsDelta() && p.packetEntityFullPackets == 0 {
+		p.resetEntityBaselines()
+	}
+
+	// Entities created by deltas start from the given baseline slot. When
+	// asked to update the baseline, the other slot is replaced with a copy of
+	// it, then updated with the state of each entity in this message.
+	baseline := int(m.GetBaseline()) & 1
+	updateBaseline := m.GetUpdateBaseline()
+	if updateBaseline {
+		p.copyEntityBaselines(baseline, 1-baseline)
 	}
 
-	// Updates pending
+	// Updates and property changes pending
 	updates := []*packetEntityUpdate{}
+	changes := []*entityPropertyChange{}
 
 	r := NewReader(m.GetEntityData())
 	index := int32(-1)
@@ -123,6 +199,11 @@ func (p *Parser) onCSVCMsg_PacketEntities(m *dota.CSVCMsg_PacketEntities) error
 
 	// Iterate over all entries
 	for i := 0; i < int(m.GetUpdatedEntries()); i++ {
+		// Bail out if the buffer has run out.
+		if r.err != nil {
+			return r.err
+		}
+
 		// Read the index delta from the buffer. This is an implementation
 		// from Alice. An alternate implementation from Yasha has the same result.
 		delta := r.readUBitVar()
@@ -159,6 +240,8 @@ func (p *Parser) onCSVCMsg_PacketEntities(m *dota.CSVCMsg_PacketEntities) error
 				ClassId:    int32(r.readBits(p.classIdSize)),
 				Serial:     int32(r.readBits(17)),
 				Properties: NewProperties(),
+				Active:     true,
+				parser:     p,
 			}
 
 			// We don't know what this is used for.
@@ -166,55 +249,108 @@ func (p *Parser) onCSVCMsg_PacketEntities(m *dota.CSVCMsg_PacketEntities) error
 
 			// Get the associated class
 			if pe.ClassName, ok = p.ClassInfo[pe.ClassId]; !ok {
-				_panicf("unable to find class %d", pe.ClassId)
+				return _errorf("unable to find class %d", pe.ClassId)
 			}
 
 			// Get the associated baseline
 			if pe.ClassBaseline, ok = p.ClassBaselines[pe.ClassId]; !ok {
-				_panicf("unable to find class baseline %d", pe.ClassId)
+				return _errorf("unable to find class baseline %d", pe.ClassId)
 			}
 
 			// Get the associated serializer
 			if pe.flatTbl, ok = p.serializers[pe.ClassName][0]; !ok {
-				_panicf("unable to find serializer for class %s", pe.ClassName)
+				return _errorf("unable to find serializer for class %s", pe.ClassName)
+			}
+
+			// Start from the entity baseline, if there's one for this class.
+			// Full updates start from the class baseline alone.
+			if eb, ok := p.entityBaselines[baseline][index]; ok && eb.classId == pe.ClassId && m.GetIsDelta() {
+				pe.Properties.Merge(eb.properties)
 			}
 
 			// Register the packetEntity with the parser.
-			p.PacketEntities[index] = pe
+			entities[index] = pe
 
 			// Read properties
-			pe.Properties.Merge(ReadProperties(r, pe.flatTbl))
+			props, err := ReadProperties(r, pe.flatTbl)
+			if err != nil {
+				return _errorf("unable to read properties for entity %d (%s): %w", index, pe.ClassName, err)
+			}
+			pe.Properties.Merge(props)
+			if !resync {
+				changes = p.recordCreateChanges(changes, pe)
+			}
 
 		case EntityEventType_Update:
 			// Find the existing packetEntity
-			pe, ok = p.PacketEntities[index]
+			pe, ok = entities[index]
 			if !ok {
-				_panicf("unable to find packet entity %d for update", index)
+				return _errorf("unable to find packet entity %d for update", index)
 			}
 
 			// Read properties and update the packetEntity
-			pe.Properties.Merge(ReadProperties(r, pe.flatTbl))
+			props, err := ReadProperties(r, pe.flatTbl)
+			if err != nil {
+				return _errorf("unable to read properties for entity %d (%s): %w", index, pe.ClassName, err)
+			}
+			if !resync {
+				changes = p.recordPropertyChanges(changes, pe, props)
+			}
+			pe.Properties.Merge(props)
+
+			// An update to an inactive entity means it's entered the PVS again.
+			if !pe.Active {
+				pe.Active = true
+				eventType = EntityEventType_Enter
+			}
 
 		case EntityEventType_Delete:
-			if pe, ok = p.PacketEntities[index]; !ok {
-				_panicf("unable to find packet entity %d for delete", index)
+			if pe, ok = entities[index]; !ok {
+				return _errorf("unable to find packet entity %d for delete", index)
 			}
 
-			delete(p.PacketEntities, index)
+			pe.Active = false
+			delete(entities, index)
 
 		case EntityEventType_Leave:
-			// TODO: Decide how we want to handle this
+			if pe, ok = entities[index]; !ok {
+				return _errorf("unable to find packet entity %d for leave", index)
+			}
+
+			pe.Active = false
+		}
+
+		// Save the state of the entity to the updated baseline.
+		if updateBaseline && pe.Active {
+			p.entityBaselines[1-baseline][index] = &entityBaseline{pe.ClassId, pe.Properties.clone()}
 		}
 
 		// Add the update to the list of pending updates.
 		updates = append(updates, &packetEntityUpdate{pe, eventType})
 	}
 
+	if r.err != nil {
+		return r.err
+	}
+
 	// Update the full packet count.
 	if !m.GetIsDelta() {
 		p.packetEntityFullPackets += 1
 	}
 
+	// Resync with the full update, offering only the changes it makes.
+	if resync {
+		var err error
+		if updates, changes, err = p.resyncPacketEntities(entities); err != nil {
+			return err
+		}
+	}
+
+	// Don't offer updates before the start of a range.
+	if p.skipping {
+		return nil
+	}
+
 	// Offer all packet entity updates to callback handlers. This is done
 	// only after all updates have been processed to ensure consistent state.
 	for _, u := range updates {
@@ -223,7 +359,121 @@ func (p *Parser) onCSVCMsg_PacketEntities(m *dota.CSVCMsg_PacketEntities) error
 				return err
 			}
 		}
+		if err := p.callEntityListeners(u.pe, u.t); err != nil {
+			return err
+		}
+	}
+
+	return p.callPropertyChanges(changes)
+}
+
+// Reconciles the entities built up from deltas with those read from a full
+// update, replacing any state that has drifted. Returns the events and
+// property changes that bring handlers up to date, which are none when the
+// state was consistent. Fails on any difference if CheckEntityConsistency is
+// set.
+func (p *Parser) resyncPacketEntities(entities map[int32]*PacketEntity) ([]*packetEntityUpdate, []*entityPropertyChange, error) {
+	indexes := make([]int, 0, len(entities))
+	for index := range entities {
+		indexes = append(indexes, int(index))
+	}
+	for index := range p.PacketEntities {
+		if _, ok := entities[index]; !ok {
+			indexes = append(indexes, int(index))
+		}
+	}
+	sort.Ints(indexes)
+
+	updates := []*packetEntityUpdate{}
+	changes := []*entityPropertyChange{}
+	for _, i := range indexes {
+		index := int32(i)
+		pe, had := p.PacketEntities[index]
+		full, has := entities[index]
+
+		// Inactive entities may be left out of a full update.
+		diff := diffPacketEntities(pe, full)
+		if p.CheckEntityConsistency && diff != "" && (has || pe.Active) {
+			return nil, nil, _errorf("%w: entity %d: %s", ErrEntityStateMismatch, index, diff)
+		}
+
+		switch {
+		case !has:
+			pe.Active = false
+			delete(p.PacketEntities, index)
+			updates = append(updates, &packetEntityUpdate{pe, EntityEventType_Delete})
+
+		case !had || pe.ClassId != full.ClassId || pe.Serial != full.Serial:
+			if had {
+				pe.Active = false
+				updates = append(updates, &packetEntityUpdate{pe, EntityEventType_Delete})
+			}
+			p.PacketEntities[index] = full
+			updates = append(updates, &packetEntityUpdate{full, EntityEventType_Create})
+			changes = p.recordCreateChanges(changes, full)
+
+		default:
+			// Keep the existing entity, so that references to it stay valid.
+			if diff != "" {
+				changes = p.recordStateChanges(changes, pe, full)
+			}
+			pe.ClassBaseline = full.ClassBaseline
+			pe.Properties = full.Properties
+			if !pe.Active {
+				pe.Active = true
+				updates = append(updates, &packetEntityUpdate{pe, EntityEventType_Enter})
+			} else if diff != "" {
+				updates = append(updates, &packetEntityUpdate{pe, EntityEventType_Update})
+			}
+		}
+	}
+
+	return updates, changes, nil
+}
+
+// Describes the first difference between an entity built up from deltas and
+// the same entity read from a full update, or returns "" if they're the same.
+func diffPacketEntities(pe, full *PacketEntity) string {
+	switch {
+	case pe == nil:
+		return "missing before full update"
+	case full == nil:
+		return "missing from full update"
+	case pe.ClassId != full.ClassId:
+		return _sprintf("class %s, full update has %s", pe.ClassName, full.ClassName)
+	case pe.Serial != full.Serial:
+		return _sprintf("serial %d, full update has %d", pe.Serial, full.Serial)
 	}
 
-	return nil
+	for _, k := range sortedPropertyKeys(pe.ClassBaseline, pe.Properties, full.ClassBaseline, full.Properties) {
+		v, ok := pe.Fetch(k)
+		fullV, fullOk := full.Fetch(k)
+		if ok != fullOk || !reflect.DeepEqual(v, fullV) {
+			return _sprintf("property %s is %v, full update has %v", k, v, fullV)
+		}
+	}
+
+	return ""
+}
+
+// The state of an entity saved as a baseline for entities created later at
+// the same index.
+type entityBaseline struct {
+	classId    int32
+	properties *Properties
+}
+
+// Clears both entity baseline slots.
+func (p *Parser) resetEntityBaselines() {
+	for i := range p.entityBaselines {
+		p.entityBaselines[i] = make(map[int32]*entityBaseline)
+	}
+}
+
+// Replaces an entity baseline slot with a copy of another.
+func (p *Parser) copyEntityBaselines(from, to int) {
+	p.entityBaselines[to] = make(map[int32]*entityBaseline, len(p.entityBaselines[from]))
+	for index, eb := range p.entityBaselines[from] {
+		p.entityBaselines[to][index] = eb
+	}
 }
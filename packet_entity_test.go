@@ -0,0 +1,398 @@
+package manta
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// An entry in a test PacketEntities message.
+type testEntityEntry struct {
+	index   int32
+	t       EntityEventType
+	classId int32
+	serial  int32
+	values  map[int]uint32 // values of CTest fields by index
+}
+
+// Writes the huffman code of a field path operation.
+func (w *testBitWriter) writeFieldPathOp(name string) {
+	var find func(node HuffmanTree, path []uint64) []uint64
+	find = func(node HuffmanTree, path []uint64) []uint64 {
+		if node.IsLeaf() {
+			if fieldpathLookup[node.Value()].Name == name {
+				return path
+			}
+			return nil
+		}
+		if p := find(node.Left(), append(path, 0)); p != nil {
+			return p
+		}
+		return find(node.Right(), append(path, 1))
+	}
+
+	for _, b := range find(huf, []uint64{}) {
+		w.write(b, 1)
+	}
+}
+
+// Writes the field paths and values of properties of the CTest class.
+func (w *testBitWriter) writeTestProperties(values map[int]uint32) {
+	fields := make([]int, 0)
+	for i := range values {
+		fields = append(fields, i)
+	}
+	sort.Ints(fields)
+
+	last := -1
+	for _, i := range fields {
+		w.writeFieldPathOp([]string{"PlusOne", "PlusTwo", "PlusThree", "PlusFour"}[i-last-1])
+		last = i
+	}
+	w.writeFieldPathOp("FieldPathEncodeFinish")
+
+	for _, i := range fields {
+		w.write(uint64(values[i]), 8)
+	}
+}
+
+// Packs entries for a PacketEntities message, in increasing order of index.
+// Values must be below 128.
+func makeTestPacketEntities(entries ...testEntityEntry) []byte {
+	w := &testBitWriter{}
+	index := int32(-1)
+	for _, e := range entries {
+		w.write(uint64(e.index-index-1), 6)
+		index = e.index
+
+		switch e.t {
+		case EntityEventType_Create:
+			w.write(0, 1)
+			w.write(1, 1)
+			w.write(uint64(e.classId), 4)
+			w.write(uint64(e.serial), 17)
+			w.write(0, 8)
+			w.writeTestProperties(e.values)
+		case EntityEventType_Update:
+			w.write(0, 1)
+			w.write(0, 1)
+			w.writeTestProperties(e.values)
+		case EntityEventType_Leave:
+			w.write(1, 1)
+			w.write(0, 1)
+		case EntityEventType_Delete:
+			w.write(1, 1)
+			w.write(1, 1)
+		}
+	}
+	return w.buf
+}
+
+// A delta PacketEntities message with the given entries.
+func makeTestPacketEntitiesMessage(entries ...testEntityEntry) *dota.CSVCMsg_PacketEntities {
+	return &dota.CSVCMsg_PacketEntities{
+		IsDelta:        proto.Bool(true),
+		UpdatedEntries: proto.Int32(int32(len(entries))),
+		EntityData:     makeTestPacketEntities(entries...),
+	}
+}
+
+// Creates a parser with a single entity class "CTest" with class id 1 and
+// integer fields m_iCurrentLevel, m_iCurrentCharges and m_iHealth, recording
+// the entity events offered to handlers.
+func newTestEntityParser(t *testing.T) (*Parser, *[]string) {
+	parser, err := NewParser(makeTestReplayMinimal())
+	assert.NoError(t, err)
+
+	parser.classIdSize = 4
+	parser.ClassInfo[1] = "CTest"
+	parser.ClassBaselines[1] = NewProperties()
+	ser := &dt{Name: "CTest"}
+	for _, name := range []string{"m_iCurrentLevel", "m_iCurrentCharges", "m_iHealth"} {
+		ser.Properties = append(ser.Properties, &dt_property{
+			Field: &dt_field{Name: name, Serializer: &PropertySerializer{}},
+		})
+	}
+	parser.serializers = map[string]map[int32]*dt{"CTest": {0: ser}}
+
+	events := make([]string, 0)
+	parser.OnPacketEntity(func(pe *PacketEntity, t EntityEventType) error {
+		events = append(events, _sprintf("%d %d", pe.Index, t))
+		return nil
+	})
+
+	return parser, &events
+}
+
+func TestPacketEntityLeave(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, events := newTestEntityParser(t)
+
+	scenarios := []struct {
+		entries  []testEntityEntry
+		expected []string
+		active   map[int32]bool
+	}{
+		{
+			[]testEntityEntry{{1, EntityEventType_Create, 1, 7, nil}, {2, EntityEventType_Create, 1, 8, nil}},
+			[]string{"1 1", "2 1"},
+			map[int32]bool{1: true, 2: true},
+		},
+		{
+			[]testEntityEntry{{1, EntityEventType_Leave, 0, 0, nil}, {2, EntityEventType_Update, 0, 0, nil}},
+			[]string{"1 4", "2 2"},
+			map[int32]bool{1: false, 2: true},
+		},
+		{
+			// Re-entering is reported as such, and only once.
+			[]testEntityEntry{{1, EntityEventType_Update, 0, 0, nil}},
+			[]string{"1 5"},
+			map[int32]bool{1: true, 2: true},
+		},
+		{
+			[]testEntityEntry{{1, EntityEventType_Update, 0, 0, nil}, {2, EntityEventType_Leave, 0, 0, nil}},
+			[]string{"1 2", "2 4"},
+			map[int32]bool{1: true, 2: false},
+		},
+		{
+			// Inactive entities can be deleted without entering again.
+			[]testEntityEntry{{2, EntityEventType_Delete, 0, 0, nil}},
+			[]string{"2 3"},
+			map[int32]bool{1: true},
+		},
+	}
+
+	for i, s := range scenarios {
+		*events = (*events)[:0]
+		assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(s.entries...)), "scenario %d", i)
+		assert.Equal(s.expected, *events, "scenario %d", i)
+
+		active := make(map[int32]bool)
+		for index, pe := range parser.PacketEntities {
+			active[index] = pe.Active
+		}
+		assert.Equal(s.active, active, "scenario %d", i)
+	}
+
+	// Entities must exist to leave.
+	err := parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(testEntityEntry{5, EntityEventType_Leave, 0, 0, nil}))
+	assert.Error(err)
+}
+
+// A full PacketEntities message with the given entries, which must all be
+// creates.
+func makeTestPacketEntitiesFull(entries ...testEntityEntry) *dota.CSVCMsg_PacketEntities {
+	m := makeTestPacketEntitiesMessage(entries...)
+	m.IsDelta = proto.Bool(false)
+	return m
+}
+
+func TestPacketEntityResync(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, events := newTestEntityParser(t)
+
+	full := []testEntityEntry{
+		{1, EntityEventType_Create, 1, 7, nil},
+		{2, EntityEventType_Create, 1, 8, nil},
+		{4, EntityEventType_Create, 1, 10, nil},
+	}
+
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(
+		testEntityEntry{1, EntityEventType_Create, 1, 7, nil},
+		testEntityEntry{2, EntityEventType_Create, 1, 8, nil},
+		testEntityEntry{3, EntityEventType_Create, 1, 9, nil},
+	)))
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{2, EntityEventType_Delete, 0, 0, nil},
+	)))
+
+	// Drift away from the state in the full update.
+	hero := parser.PacketEntities[1]
+	hero.Properties.KV["m_iHealth"] = int32(10)
+
+	// Only the differences are offered to handlers.
+	*events = (*events)[:0]
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(full...)))
+	assert.Equal([]string{"1 2", "2 1", "3 3", "4 1"}, *events)
+	assert.Len(parser.PacketEntities, 3)
+	assert.Same(hero, parser.PacketEntities[1])
+	_, ok := hero.Fetch("m_iHealth")
+	assert.False(ok)
+
+	// A consistent full update changes nothing.
+	*events = (*events)[:0]
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(full...)))
+	assert.Empty(*events)
+}
+
+func TestPacketEntityConsistencyCheck(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+	parser.CheckEntityConsistency = true
+
+	full := []testEntityEntry{
+		{1, EntityEventType_Create, 1, 7, nil},
+		{2, EntityEventType_Create, 1, 8, nil},
+	}
+
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(full...)))
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(full...)))
+
+	// Entities that have left the PVS may be missing.
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{3, EntityEventType_Create, 1, 9, nil},
+	)))
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{3, EntityEventType_Leave, 0, 0, nil},
+	)))
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(full...)))
+
+	parser.PacketEntities[2].Properties.KV["m_iHealth"] = int32(10)
+	err := parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(full...))
+	assert.ErrorIs(err, ErrEntityStateMismatch)
+	assert.Contains(err.Error(), "entity 2: property m_iHealth")
+}
+
+func TestPacketEntityBaselines(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+	parser.ClassInfo[2] = "CTest"
+	parser.ClassBaselines[2] = NewProperties()
+
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(
+		testEntityEntry{1, EntityEventType_Create, 1, 7, nil},
+	)))
+	parser.PacketEntities[1].Properties.KV["m_iHealth"] = int32(10)
+
+	// Save the entity to baseline slot 1.
+	m := makeTestPacketEntitiesMessage(testEntityEntry{1, EntityEventType_Update, 0, 0, nil})
+	m.UpdateBaseline = proto.Bool(true)
+	assert.NoError(parser.onCSVCMsg_PacketEntities(m))
+
+	scenarios := []struct {
+		baseline int32
+		classId  int32
+		expected bool
+	}{
+		{1, 1, true},
+		{0, 1, false}, // other slot
+		{1, 2, false}, // other class
+	}
+
+	for _, s := range scenarios {
+		assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+			testEntityEntry{1, EntityEventType_Delete, 0, 0, nil},
+		)))
+
+		m := makeTestPacketEntitiesMessage(testEntityEntry{1, EntityEventType_Create, s.classId, 8, nil})
+		m.Baseline = proto.Int32(s.baseline)
+		assert.NoError(parser.onCSVCMsg_PacketEntities(m))
+
+		_, ok := parser.PacketEntities[1].Fetch("m_iHealth")
+		assert.Equal(s.expected, ok, "baseline %d class %d", s.baseline, s.classId)
+	}
+}
+
+func TestPacketEntityBaselinesFullUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+
+	// Save an entity with health to baseline slot 1.
+	m := makeTestPacketEntitiesFull(testEntityEntry{1, EntityEventType_Create, 1, 7, map[int]uint32{2: 100}})
+	m.UpdateBaseline = proto.Bool(true)
+	assert.NoError(parser.onCSVCMsg_PacketEntities(m))
+
+	// A later full update creating an entity there doesn't pick it up.
+	m = makeTestPacketEntitiesFull(testEntityEntry{1, EntityEventType_Create, 1, 8, map[int]uint32{0: 1}})
+	m.Baseline = proto.Int32(1)
+	assert.NoError(parser.onCSVCMsg_PacketEntities(m))
+
+	_, ok := parser.PacketEntities[1].Fetch("m_iHealth")
+	assert.False(ok)
+	assert.Equal(uint32(1), parser.PacketEntities[1].Properties.KV["m_iCurrentLevel"])
+}
+
+func TestStrictBaselines(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+
+	// A baseline followed by more data than its properties account for.
+	w := &testBitWriter{}
+	w.writeTestProperties(map[int]uint32{2: 10})
+	w.write(0, 16)
+	item := &StringTableItem{Key: "1", Value: w.buf}
+
+	assert.NoError(parser.updateInstanceBaselineItem(item))
+	assert.Equal(uint32(10), parser.ClassBaselines[1].KV["m_iHealth"])
+
+	parser.StrictBaselines = true
+	assert.Error(parser.updateInstanceBaselineItem(item))
+}
+
+func TestPacketEntityAllProperties(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+	parser.ClassBaselines[1].KV["m_iHealth"] = uint32(100)
+	parser.ClassBaselines[1].KV["m_iCurrentCharges"] = uint32(0)
+
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(
+		testEntityEntry{1, EntityEventType_Create, 1, 7, map[int]uint32{1: 3}},
+	)))
+
+	pe := parser.PacketEntities[1]
+	assert.Equal(map[string]interface{}{
+		"m_iCurrentCharges": uint32(3),
+		"m_iHealth":         uint32(100),
+	}, pe.AllProperties())
+	assert.Equal([]string{"m_iCurrentCharges", "m_iHealth"}, pe.Keys())
+}
+
+func TestPacketEntityKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	field := func(name string) *dt_property {
+		return &dt_property{Field: &dt_field{Name: name}}
+	}
+
+	items := &dt{Name: "m_hItems", Properties: []*dt_property{field("0000"), field("0001")}}
+	ser := &dt{Name: "CTest", Properties: []*dt_property{
+		field("m_iHealth"),
+		{Field: &dt_field{Name: "m_hItems"}, Table: items},
+		field("m_iLevel"),
+	}}
+
+	pe := &PacketEntity{
+		ClassBaseline: &Properties{KV: map[string]interface{}{
+			"m_iLevel":      1,
+			"m_hItems.0001": 2,
+			"zzz":           3,
+		}},
+		Properties: &Properties{KV: map[string]interface{}{
+			"m_hItems.0000": 4,
+			"m_hItems":      5,
+			"aaa":           6,
+			"m_iHealth":     7,
+		}},
+		flatTbl: ser,
+	}
+
+	assert.Equal([]string{
+		"m_iHealth",
+		"m_hItems",
+		"m_hItems.0000",
+		"m_hItems.0001",
+		"m_iLevel",
+		"aaa",
+		"zzz",
+	}, pe.Keys())
+}
@@ -0,0 +1,144 @@
+package manta
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// A Source provides a replay to be parsed by ParseMany. Sources should be
+// comparable, so that they can be used as map keys to identify replays.
+type Source interface {
+	// Opens the replay for reading. The reader is closed once the replay has
+	// been parsed.
+	Open() (io.ReadCloser, error)
+}
+
+// A Source reading a replay from a file on disk.
+type FileSource string
+
+func (s FileSource) Open() (io.ReadCloser, error) {
+	return os.Open(string(s))
+}
+
+// A Source reading a replay held in memory.
+type bytesSource struct {
+	buf []byte
+}
+
+// Create a new Source reading a replay held in memory.
+func NewBytesSource(buf []byte) Source {
+	return &bytesSource{buf}
+}
+
+func (s *bytesSource) Open() (io.ReadCloser, error) {
+	return ioutil.NopCloser(bytes.NewReader(s.buf)), nil
+}
+
+// The outcome of parsing a single Source with ParseMany.
+type Result struct {
+	// The source that was parsed.
+	Source Source
+
+	// The tick associated with the last message processed.
+	Tick uint32
+
+	// The error encountered opening or parsing the source, if any.
+	Err error
+}
+
+// Parse many replays in parallel using a pool of workers, returning a Result
+// for each source in the same order. A workers count below 1 uses one worker
+// per CPU. Each source is only opened once a worker is ready for it, so at
+// most workers replays are being read at any time. The setup function is
+// called with each new parser before parsing starts, from the worker's
+// goroutine, and is used to register callbacks. Callbacks for different
+// replays run concurrently, so any state they share must be synchronized.
+// Use Parser.Source() to tell which replay a parser is reading. Sources not
+// yet started when the context is cancelled fail with ctx.Err().
+func ParseMany(ctx context.Context, sources []Source, workers int, setup func(*Parser) error) []Result {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	results := make([]Result, len(sources))
+	jobs := make(chan int)
+
+	// Start the workers, each writing results for the sources it's given.
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j] = parseSource(ctx, sources[j], setup)
+			}
+		}()
+	}
+
+	// Hand out sources until we run out or are cancelled.
+feed:
+	for i := range sources {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			for ; i < len(sources); i++ {
+				results[i] = Result{Source: sources[i], Err: ctx.Err()}
+			}
+			break feed
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// Parses a single source for ParseMany.
+func parseSource(ctx context.Context, s Source, setup func(*Parser) error) Result {
+	result := Result{Source: s}
+
+	r, err := s.Open()
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer r.Close()
+
+	parser, err := NewStreamParser(r)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	parser.source = s
+
+	if setup != nil {
+		if err := parser.runSetup(setup); err != nil {
+			parser.close()
+			result.Err = err
+			return result
+		}
+	}
+
+	result.Err = parser.StartContext(ctx)
+	result.Tick = parser.Tick
+	return result
+}
+
+// Calls the ParseMany setup function with the parser, returning any panic it
+// raises as an error like those raised by callbacks.
+func (p *Parser) runSetup(setup func(*Parser) error) (err error) {
+	defer p.recoverPanic(&err)
+	return setup(p)
+}
+
+// Returns the source being parsed when the parser was created by ParseMany,
+// otherwise nil.
+func (p *Parser) Source() Source {
+	return p.source
+}
@@ -0,0 +1,102 @@
+package manta
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMany(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "manta")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "replay.dem")
+	assert.NoError(ioutil.WriteFile(path, makeTestReplayMinimal(), 0644))
+
+	sources := []Source{FileSource(path), FileSource(filepath.Join(dir, "missing.dem"))}
+	for i := 0; i < 10; i++ {
+		sources = append(sources, NewBytesSource(makeTestReplayMinimal()))
+	}
+	sources = append(sources, NewBytesSource(magicSource1))
+
+	// Record the server name seen for each source.
+	var mu sync.Mutex
+	names := make(map[Source]string)
+	setup := func(p *Parser) error {
+		p.Callbacks.OnCDemoFileHeader(func(m *dota.CDemoFileHeader) error {
+			mu.Lock()
+			defer mu.Unlock()
+			names[p.Source()] = m.GetServerName()
+			return nil
+		})
+		return nil
+	}
+
+	results := ParseMany(context.Background(), sources, 3, setup)
+	if !assert.Len(results, len(sources)) {
+		return
+	}
+
+	for i, r := range results {
+		assert.Equal(sources[i], r.Source, "source %d", i)
+		switch i {
+		case 1:
+			assert.True(os.IsNotExist(r.Err), "source %d", i)
+		case len(sources) - 1:
+			assert.True(errors.Is(r.Err, ErrUnexpectedMagic), "source %d", i)
+		default:
+			assert.NoError(r.Err, "source %d", i)
+			assert.Equal(uint32(100), r.Tick, "source %d", i)
+		}
+	}
+
+	assert.Equal("test server", names[sources[0]])
+}
+
+func TestParseManySetupError(t *testing.T) {
+	assert := assert.New(t)
+
+	setupErr := errors.New("setup failed")
+	results := ParseMany(context.Background(), []Source{NewBytesSource(makeTestReplayMinimal())}, 1, func(p *Parser) error {
+		return setupErr
+	})
+	assert.Equal(setupErr, results[0].Err)
+}
+
+func TestParseManySetupPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	sources := []Source{NewBytesSource(makeTestReplayMinimal()), NewBytesSource(makeTestReplayMinimal())}
+	results := ParseMany(context.Background(), sources, 1, func(p *Parser) error {
+		if p.Source() == sources[0] {
+			panic("setup panicked")
+		}
+		return nil
+	})
+	if assert.Error(results[0].Err) {
+		assert.Contains(results[0].Err.Error(), "setup panicked")
+	}
+	assert.NoError(results[1].Err)
+}
+
+func TestParseManyCancelled(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sources := []Source{NewBytesSource(makeTestReplayMinimal()), NewBytesSource(makeTestReplayMinimal())}
+	for _, r := range ParseMany(ctx, sources, 0, nil) {
+		assert.Equal(context.Canceled, r.Err)
+	}
+}
@@ -2,7 +2,12 @@ package manta
 
 import (
 	"bytes"
-	"io/ioutil"
+	"context"
+	"io"
+	"math"
+	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/dotabuff/manta/dota"
 	"github.com/golang/snappy"
@@ -27,6 +32,43 @@ type Parser struct {
 	// Determines whether or not PacketEntity events are processed.
 	ProcessPacketEntities bool
 
+	// Determines whether or not entities built up from deltas are compared
+	// against each full update, making Start() return ErrEntityStateMismatch
+	// on any difference. Otherwise full updates silently correct any drift.
+	// Useful for verifying the parser against new game builds.
+	CheckEntityConsistency bool
+
+	// Determines whether or not a replay that ends partway through a message
+	// is accepted. When set, Start() stops at the last complete message and
+	// returns without error, setting Truncated. Useful for replays downloaded
+	// mid-match or cut off during upload.
+	AllowTruncated bool
+
+	// Set when the replay ended partway through a message and AllowTruncated
+	// is set. Tick then holds the last tick that was completely processed.
+	Truncated bool
+
+	// Determines whether or not messages of a type without a definition cause
+	// Start() to return ErrUnknownMessage. By default they're offered to the
+	// OnUnknownDemo and OnUnknownPacket callbacks and otherwise skipped, as
+	// new game patches often add messages before the protos are updated.
+	FailOnUnknownMessages bool
+
+	// Determines whether or not panics raised while decoding are recovered
+	// and returned from Start() as errors. Disable to get a stack trace when
+	// debugging the parser itself.
+	RecoverPanics bool
+
+	// Determines whether or not instance baselines are checked for data left
+	// over after their properties have been read, which indicates that the
+	// send tables don't match the replay. Start() then returns an error
+	// rather than carrying on with properties that may be wrong.
+	StrictBaselines bool
+
+	// Determines whether or not the time spent handling each message type is
+	// recorded, to be reported by Profile().
+	Profiling bool
+
 	// Stores the game build.
 	GameBuild uint32
 
@@ -41,34 +83,132 @@ type Parser struct {
 	gameEventTypes          map[string]*gameEventType
 	hasClassInfo            bool
 	packetEntityHandlers    []packetEntityHandler
+	entityListeners         []*entityListener
+	entityClassTable        map[int32][]*entityListener
+	entityPropertyListeners []*entityPropertyListener
+	entityPropertyClasses   map[string][]*entityPropertyListener
+	tickStartHandlers       []tickHandler
+	tickEndHandlers         []tickHandler
+	tickOpen                bool
+	openTick                uint32
 	packetEntityFullPackets int
+	entityBaselines         [2]map[int32]*entityBaseline
 	serializers             map[string]map[int32]*dt
 	spawnGroups             map[uint32]*spawnGroup
 
-	reader            *Reader
-	isStopping        bool
+	internalCallbacks *Callbacks
+	skipping          bool
+	stream            *stream
+	seeker            io.ReadSeeker
+	seekBase          int64
+	index             *ReplayIndex
+	profile           map[profileKey]*ProfileEntry
+	messagePriorities map[int32]int
+	source            Source
+	fileInfoOffset    int64
+	closers           []io.Closer
+	isStopping        int32
 	AfterStopCallback func()
 }
 
-// Create a new Parser from a file on disk.
+// Create a new Parser from a file on disk. The file is read incrementally
+// and closed once Start() returns. Files compressed with bzip2, gzip or zstd
+// are decompressed on the fly.
 func NewParserFromFile(path string) (*Parser, error) {
-	buf, err := ioutil.ReadFile(path)
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parser, err := NewStreamParser(f)
 	if err != nil {
+		f.Close()
 		return nil, err
 	}
 
-	return NewParser(buf)
+	parser.closers = append(parser.closers, f)
+	return parser, nil
 }
 
-// Create a new parser from a byte slice.
+// Create a new parser from a byte slice. Compressed replays are accepted
+// in the same formats as NewStreamParser.
 func NewParser(buf []byte) (*Parser, error) {
-	// Create a new parser with an internal reader for the given buffer.
+	return NewStreamParser(bytes.NewReader(buf))
+}
+
+// Create a new parser from an io.Reader. Outer messages are read from the
+// reader as they are needed, so the replay never has to be held in memory
+// in its entirety. This allows parsing from a pipe, a decompressor or an
+// HTTP response body. Replays wrapped in bzip2, gzip or zstd compression are
+// detected and decompressed on the fly.
+func NewStreamParser(r io.Reader) (*Parser, error) {
+	// Remember where a seekable source starts, before anything is buffered.
+	seeker, _ := r.(io.ReadSeeker)
+	seekBase := int64(0)
+	if seeker != nil {
+		var err error
+		if seekBase, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			seeker = nil
+		}
+	}
+
+	// Unwrap compressed replays before looking at the magic.
+	rc, compressed, err := newDecompressor(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Offsets within a compressed replay don't map to the source, so only
+	// uncompressed replays can be seeked.
+	if compressed {
+		seeker = nil
+	}
+
+	// Create a new parser with an internal stream for the given reader.
+	parser := newParser()
+	parser.stream = newStream(rc)
+	parser.seeker = seeker
+	parser.seekBase = seekBase
+	parser.closers = []io.Closer{rc}
+
+	// Parse out the header, ensuring that it's valid.
+	magic, err := parser.stream.readBytes(8)
+	if err != nil {
+		rc.Close()
+		return nil, truncated(err)
+	}
+	if bytes.Equal(magic, magicSource1) {
+		rc.Close()
+		return nil, ErrSource1Replay
+	}
+	if !bytes.Equal(magic, magicSource2) {
+		rc.Close()
+		return nil, _errorf("%w: expected %s, got %s", ErrUnexpectedMagic, magicSource2, magic)
+	}
+
+	// The next 8 bytes are two int32s. The first is the offset of the
+	// CDemoFileInfo message near the end of the file, the second appears to
+	// be the offset of the spawn groups.
+	offsets, err := parser.stream.readBytes(8)
+	if err != nil {
+		rc.Close()
+		return nil, truncated(err)
+	}
+	parser.fileInfoOffset = int64(littleEndian.Uint32(offsets[0:4]))
+
+	return parser, nil
+}
+
+// Creates a new parser with internal handlers registered, reading from an
+// empty stream.
+func newParser() *Parser {
 	parser := &Parser{
 		Callbacks: &Callbacks{},
 		Tick:      0,
 		NetTick:   0,
 
 		ProcessPacketEntities: true,
+		RecoverPanics:         true,
 
 		ClassBaselines: make(map[int32]*Properties),
 		ClassInfo:      make(map[int32]string),
@@ -81,18 +221,10 @@ func NewParser(buf []byte) (*Parser, error) {
 		packetEntityHandlers: make([]packetEntityHandler, 0),
 		spawnGroups:          make(map[uint32]*spawnGroup),
 
-		reader:     NewReader(buf),
-		isStopping: false,
-	}
-
-	// Parse out the header, ensuring that it's valid.
-	if magic := parser.reader.readBytes(8); !bytes.Equal(magic, magicSource2) {
-		return nil, _errorf("unexpected magic: expected %s, got %s", magicSource2, magic)
+		stream:     newStream(bytes.NewReader(nil)),
+		isStopping: 0,
 	}
-
-	// Skip the next 8 bytes, which appear to be two int32s related to the size
-	// of the demo file. We may need them in the future, but not so far.
-	parser.reader.seekBytes(8)
+	parser.resetEntityBaselines()
 
 	// Internal handlers
 	parser.Callbacks.OnCDemoPacket(parser.onCDemoPacket)
@@ -118,30 +250,143 @@ func NewParser(buf []byte) (*Parser, error) {
 		return nil
 	})
 
-	return parser, nil
+	// Keep a copy of the internal handlers, which are all that's invoked
+	// while skipping to the start of a range. Handlers registered later are
+	// appended beyond the length of the copied slices, so aren't seen.
+	internal := *parser.Callbacks
+	parser.internalCallbacks = &internal
+
+	return parser
 }
 
 // Start parsing the replay. Will stop processing new events after Stop() is called.
+// Errors encountered while processing a message, including those returned
+// from callbacks, are returned as a *DecodeError.
 func (p *Parser) Start() error {
+	return p.startRange(context.Background(), 0, math.MaxUint32)
+}
+
+// Start parsing the replay, checking for cancellation of the given context
+// between outer messages. Returns ctx.Err() if the context is cancelled
+// before parsing completes.
+func (p *Parser) StartContext(ctx context.Context) error {
+	return p.startRange(ctx, 0, math.MaxUint32)
+}
+
+// Start parsing the replay, invoking callbacks only for messages between
+// fromTick and toTick inclusive. Messages before fromTick are processed only
+// as far as needed to maintain parser state, without invoking callbacks,
+// entity handlers or game event handlers. For seekable replays, state is
+// restored from the last full packet before fromTick (see SeekToTick()), so
+// entities aren't decoded for anything before it. Stops after toTick.
+func (p *Parser) StartRange(fromTick, toTick uint32) error {
+	return p.startRange(context.Background(), fromTick, toTick)
+}
+
+func (p *Parser) startRange(ctx context.Context, fromTick, toTick uint32) error {
 	var msg *outerMessage
 	var err error
 
 	defer p.afterStop()
 
+	// Jump as close to the start of the range as we can.
+	if fromTick > 0 && p.seeker != nil {
+		p.skipping = true
+		if err = p.SeekToTick(fromTick - 1); err != nil {
+			return err
+		}
+	}
+
 	// Loop through all outer messages until we're signaled to stop. Stopping
-	// happens when either the OnCDemoStop message is encountered or
-	// parser.Stop() is called programatically.
-	for !p.isStopping && p.reader.remBytes() > 0 {
+	// happens when either the OnCDemoStop message is encountered,
+	// parser.Stop() is called programatically, the context is cancelled or
+	// the stream is exhausted.
+	for !p.isStopped() && !p.stream.atEOF() {
+		if err = ctx.Err(); err != nil {
+			return err
+		}
+
+		// Remember where the message starts for error reporting.
+		offset := p.stream.pos
+
 		// Read the next outer message.
 		if msg, err = p.readOuterMessage(); err != nil {
+			return p.readError(err, offset)
+		}
+
+		// Stop once we're past the end of the range.
+		if msg.tick > toTick {
+			return p.finishTick()
+		}
+
+		// Only maintain state for messages before the start of the range.
+		p.skipping = msg.tick < fromTick
+
+		// Process the message.
+		if err = p.processOuterMessage(msg, offset); err != nil {
 			return err
 		}
+	}
+
+	// Every message of the last tick has been dispatched if we reached the
+	// end of the replay.
+	if p.stream.atEOF() {
+		return p.finishTick()
+	}
+
+	return nil
+}
+
+// Handles an error reading the outer message at the given offset, returning
+// it as a *DecodeError. A partial final message means the replay was cut
+// short, which we tolerate if configured to.
+func (p *Parser) readError(err error, offset int64) error {
+	if err == ErrTruncatedReplay && p.AllowTruncated {
+		_debugf("replay truncated at offset %d after tick %d", offset, p.Tick)
+		p.Truncated = true
+		return nil
+	}
+	return &DecodeError{Tick: p.Tick, MessageType: int32(dota.EDemoCommands_DEM_Error), Offset: offset, Cause: err}
+}
+
+// Processes an outer message read from the given offset, updating the parser
+// tick and invoking callbacks. Errors are returned as a *DecodeError.
+func (p *Parser) processOuterMessage(msg *outerMessage, offset int64) error {
+	// Update the parser tick
+	p.Tick = msg.tick
+
+	// Invoke callbacks for the given message type.
+	if err := p.callOuterMessage(msg); err != nil {
+		if e, ok := err.(*DecodeError); ok {
+			e.Offset = offset
+			return e
+		}
+		return &DecodeError{Tick: p.Tick, MessageType: msg.typeId, Offset: offset, Cause: err}
+	}
+
+	return nil
+}
 
-		// Update the parser tick
-		p.Tick = msg.tick
+// Returns the callbacks to invoke for messages. Only internal handlers are
+// invoked while skipping to the start of a range.
+func (p *Parser) dispatchCallbacks() *Callbacks {
+	if p.skipping {
+		return p.internalCallbacks
+	}
+	return p.Callbacks
+}
+
+// Handles a message of a type without a definition, either failing or
+// offering it to the given callbacks.
+func (p *Parser) callUnknownMessage(cbs []func(uint32, int32, []byte) error, t int32, raw []byte) error {
+	if p.FailOnUnknownMessages {
+		return _errorf("%w: type %d", ErrUnknownMessage, t)
+	}
 
-		// Invoke callbacks for the given message type.
-		if err = p.CallByDemoType(msg.typeId, msg.data); err != nil {
+	_debugf("skipping unknown message type %d at tick %d (%d bytes)", t, p.Tick, len(raw))
+
+	for _, fn := range cbs {
+		if err := fn(p.Tick, t, raw); err != nil {
 			return err
 		}
 	}
@@ -149,17 +394,58 @@ func (p *Parser) Start() error {
 	return nil
 }
 
+// Invokes callbacks for a single outer message.
+func (p *Parser) callOuterMessage(msg *outerMessage) (err error) {
+	defer p.recoverPanic(&err)
+	p.advanceTick(msg.tick)
+	if p.Profiling {
+		defer p.profileSince(false, msg.typeId, time.Now())
+	}
+	return p.CallByDemoType(msg.typeId, msg.data)
+}
+
+// Recovers from a panic raised while decoding, storing it as the error
+// returned by the deferring function. Does nothing unless RecoverPanics is set.
+func (p *Parser) recoverPanic(err *error) {
+	if !p.RecoverPanics {
+		return
+	}
+
+	if r := recover(); r != nil {
+		*err = panicError(r)
+	}
+}
+
 // Stop parsing the replay, causing the parser to stop processing new events.
+// Safe to call from any goroutine, including while Start() is running.
 func (p *Parser) Stop() {
-	p.isStopping = true
+	atomic.StoreInt32(&p.isStopping, 1)
+}
+
+// Determines whether or not Stop() has been called.
+func (p *Parser) isStopped() bool {
+	return atomic.LoadInt32(&p.isStopping) == 1
 }
 
 func (p *Parser) afterStop() {
+	// A tick still open was cut short, so isn't reported as having ended.
+	p.tickOpen = false
+	p.skipping = false
+	p.close()
+
 	if p.AfterStopCallback != nil {
 		p.AfterStopCallback()
 	}
 }
 
+// Releases the resources used to read the replay.
+func (p *Parser) close() {
+	for _, c := range p.closers {
+		c.Close()
+	}
+	p.closers = nil
+}
+
 // Performs a lookup on a string table by an entry index.
 func (p *Parser) LookupStringByIndex(table string, index int32) (string, bool) {
 	t, ok := p.StringTables.GetTableByName(table)
@@ -182,42 +468,91 @@ type outerMessage struct {
 	data   []byte
 }
 
-// Read the next outer message from the buffer.
+// Describes the header of a demo message, which precedes its data.
+type outerHeader struct {
+	tick       uint32
+	typeId     int32
+	compressed bool
+	size       uint32
+}
+
+// Read the next outer message from the stream.
 func (p *Parser) readOuterMessage() (*outerMessage, error) {
+	h, err := p.readOuterHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := p.readOuterData(h)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return the message
+	msg := &outerMessage{
+		tick:   h.tick,
+		typeId: h.typeId,
+		data:   buf,
+	}
+	return msg, nil
+}
+
+// Read the data following the given outer message header, decompressing it
+// if necessary.
+func (p *Parser) readOuterData(h *outerHeader) ([]byte, error) {
+	buf, err := p.stream.readBytes(h.size)
+	if err != nil {
+		return nil, truncated(err)
+	}
+
+	// If the buffer is compressed, decompress it with snappy.
+	if h.compressed {
+		if buf, err = snappy.Decode(nil, buf); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf, nil
+}
+
+// Read the header of the next outer message from the stream, leaving the
+// stream positioned at the start of its data.
+func (p *Parser) readOuterHeader() (*outerHeader, error) {
 	// Read a command header, which includes both the message type
 	// well as a flag to determine whether or not whether or not the
 	// message is compressed with snappy.
-	command := dota.EDemoCommands(p.reader.readVarUint32())
+	c, err := p.stream.readVarUint32()
+	if err != nil {
+		return nil, truncated(err)
+	}
+	command := dota.EDemoCommands(c)
 
 	// Extract the type and compressed flag out of the command
 	msgType := int32(command & ^dota.EDemoCommands_DEM_IsCompressed)
 	msgCompressed := (command & dota.EDemoCommands_DEM_IsCompressed) == dota.EDemoCommands_DEM_IsCompressed
 
 	// Read the tick that the message corresponds with.
-	tick := p.reader.readVarUint32()
+	tick, err := p.stream.readVarUint32()
+	if err != nil {
+		return nil, truncated(err)
+	}
 
 	// This appears to actually be an int32, where a -1 means pre-game.
 	if tick == 4294967295 {
 		tick = 0
 	}
 
-	// Read the size and following buffer.
-	size := int(p.reader.readVarUint32())
-	buf := p.reader.readBytes(size)
-
-	// If the buffer is compressed, decompress it with snappy.
-	if msgCompressed {
-		var err error
-		if buf, err = snappy.Decode(nil, buf); err != nil {
-			return nil, err
-		}
+	// Read the size of the following buffer.
+	size, err := p.stream.readVarUint32()
+	if err != nil {
+		return nil, truncated(err)
 	}
 
-	// Return the message
-	msg := &outerMessage{
-		tick:   tick,
-		typeId: msgType,
-		data:   buf,
+	h := &outerHeader{
+		tick:       tick,
+		typeId:     msgType,
+		compressed: msgCompressed,
+		size:       size,
 	}
-	return msg, nil
+	return h, nil
 }
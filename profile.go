@@ -0,0 +1,100 @@
+package manta
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dotabuff/manta/dota"
+)
+
+// The time spent handling messages of a single type, including unmarshaling
+// and calling callbacks.
+type ProfileEntry struct {
+	// The name of the message type, such as "DEM_Packet".
+	Name string
+
+	// The type id of the message.
+	Type int32
+
+	// Whether the message is an inner packet. The time spent on an outer
+	// message includes the time spent on the inner packets it contains.
+	Inner bool
+
+	// The number of messages handled.
+	Count int
+
+	// The total time spent handling messages.
+	Duration time.Duration
+}
+
+// A report of time spent per message type, slowest first.
+type Profile []*ProfileEntry
+
+// Formats the profile as a table.
+func (p Profile) String() string {
+	buf := bytes.NewBuffer(nil)
+	for _, e := range p {
+		fmt.Fprintf(buf, "%-50s %10d %14s\n", e.Name, e.Count, e.Duration)
+	}
+	return buf.String()
+}
+
+// Identifies a message type in the profile.
+type profileKey struct {
+	inner bool
+	t     int32
+}
+
+// Returns the time spent per message type so far. Only recorded while
+// Profiling is set.
+func (p *Parser) Profile() Profile {
+	profile := make(Profile, 0, len(p.profile))
+	for _, e := range p.profile {
+		c := *e
+		profile = append(profile, &c)
+	}
+
+	sort.Slice(profile, func(i, j int) bool {
+		if profile[i].Duration != profile[j].Duration {
+			return profile[i].Duration > profile[j].Duration
+		}
+		return profile[i].Name < profile[j].Name
+	})
+
+	return profile
+}
+
+// Records the time since start against a message type.
+func (p *Parser) profileSince(inner bool, t int32, start time.Time) {
+	elapsed := time.Since(start)
+
+	k := profileKey{inner, t}
+	e, ok := p.profile[k]
+	if !ok {
+		if p.profile == nil {
+			p.profile = make(map[profileKey]*ProfileEntry)
+		}
+		e = &ProfileEntry{Name: profileName(inner, t), Type: t, Inner: inner}
+		p.profile[k] = e
+	}
+
+	e.Count += 1
+	e.Duration += elapsed
+}
+
+// Determines the name of a message type for the profile.
+func profileName(inner bool, t int32) string {
+	if inner {
+		if name, ok := packetNames[t]; ok {
+			return name
+		}
+		return fmt.Sprintf("unknown packet %d", t)
+	}
+
+	if name, ok := dota.EDemoCommands_name[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown demo %d", t)
+}
@@ -0,0 +1,72 @@
+package manta
+
+import (
+	"testing"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfile(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMessages())
+	assert.NoError(err)
+	parser.Profiling = true
+
+	assert.NoError(On(parser, func(m *dota.CNETMsg_Tick) error { return nil }))
+
+	assert.NoError(parser.Start())
+
+	counts := make(map[string]int)
+	for _, e := range parser.Profile() {
+		counts[e.Name] = e.Count
+	}
+	assert.Equal(map[string]int{
+		"DEM_FileHeader":        1,
+		"DEM_Packet":            1,
+		"NET_Messages_net_Tick": 1,
+		"DEM_FileInfo":          1,
+	}, counts)
+	assert.Contains(parser.Profile().String(), "NET_Messages_net_Tick")
+
+	// Nothing is recorded unless profiling is enabled.
+	parser, err = NewParser(makeTestReplayMessages())
+	assert.NoError(err)
+	assert.NoError(parser.Start())
+	assert.Empty(parser.Profile())
+}
+
+func TestSkipPacketsWithoutCallbacks(t *testing.T) {
+	assert := assert.New(t)
+
+	// A string command that can't be unmarshaled, which only matters when
+	// something is listening to it.
+	replay := makeTestReplay(
+		testOuterMessage{dota.EDemoCommands_DEM_Packet, 1, &dota.CDemoPacket{
+			Data: makeTestInnerPackets(testInnerPacket{5, []byte{0xff}}),
+		}},
+	)
+
+	parser, err := NewParser(replay)
+	assert.NoError(err)
+	parser.Profiling = true
+	assert.NoError(parser.Start())
+	for _, e := range parser.Profile() {
+		assert.False(e.Inner, e.Name)
+	}
+
+	parser, err = NewParser(replay)
+	assert.NoError(err)
+	assert.NoError(On(parser, func(m *dota.CNETMsg_StringCmd) error { return nil }))
+	assert.Error(parser.Start())
+
+	// Truncated packets are still detected when skipping.
+	parser, err = NewParser(makeTestReplay(
+		testOuterMessage{dota.EDemoCommands_DEM_Packet, 1, &dota.CDemoPacket{
+			Data: makeTestInnerPackets(testInnerPacket{5, []byte{0x08, 0x01}})[:2],
+		}},
+	))
+	assert.NoError(err)
+	assert.Error(parser.Start())
+}
@@ -28,6 +28,16 @@ func (p *Properties) Merge(p2 *Properties) {
 	}
 }
 
+// Creates a copy of the properties. Values are shared, as updates replace
+// them rather than modifying them.
+func (p *Properties) clone() *Properties {
+	c := &Properties{KV: make(map[string]interface{}, len(p.KV))}
+	for k, v := range p.KV {
+		c.KV[k] = v
+	}
+	return c
+}
+
 // Fetch a value by key.
 func (p *Properties) Fetch(k string) (interface{}, bool) {
 	v, ok := p.KV[k]
@@ -95,7 +105,7 @@ func (p *Properties) FetchString(k string) (string, bool) {
 }
 
 // Reads properties using a given reader and serializer.
-func ReadProperties(r *Reader, ser *dt) (result *Properties) {
+func ReadProperties(r *Reader, ser *dt) (result *Properties, err error) {
 	// Return type
 	result = NewProperties()
 
@@ -103,7 +113,9 @@ func ReadProperties(r *Reader, ser *dt) (result *Properties) {
 	fieldPath := newFieldpath(ser, &huf)
 
 	// Get a list of the included fields
-	fieldPath.walk(r)
+	if err = fieldPath.walk(r); err != nil {
+		return nil, err
+	}
 
 	// iterate all the fields and set their corresponding values
 	for _, f := range fieldPath.fields {
@@ -124,5 +136,10 @@ func ReadProperties(r *Reader, ser *dt) (result *Properties) {
 		_debugfl(6, "Decoded: %d %s %s %v", r.pos, f.Name, f.Field.Type, result.KV[f.Name])
 	}
 
-	return result
+	// Make sure the buffer didn't run out while decoding values.
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return result, nil
 }
@@ -73,21 +73,9 @@ func decodeFloatNoscale(r *Reader, f *dt_field) interface{} {
 	return math.Float32frombits(r.readBits(int(*f.BitCount)))
 }
 
-// A list of field -> encoder types
-var qmap map[*dt_field]*QuantizedFloatDecoder
-
 func decodeQuantized(r *Reader, f *dt_field) interface{} {
-	if qmap == nil {
-		qmap = make(map[*dt_field]*QuantizedFloatDecoder, 0)
-	}
-
 	// Get the correct decoder
-	q, ok := qmap[f]
-
-	if !ok {
-		qmap[f] = InitQFD(f)
-		q = qmap[f]
-	}
+	q := f.quantizedFloatDecoder()
 
 	// Decode value
 	_debugf(
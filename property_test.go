@@ -1,6 +1,7 @@
 package manta
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/dotabuff/manta/dota"
@@ -275,7 +276,8 @@ func TestReadProperties(t *testing.T) {
 
 	// Retrieve the flattened field serializer
 	p := &Parser{}
-	fs := p.ParseSendTables(m, GetDefaultPropertySerializerTable())
+	fs, err := p.ParseSendTables(m, GetDefaultPropertySerializerTable())
+	assert.NoError(err)
 
 	// Iterate through scenarios
 	for _, s := range scenarios {
@@ -293,14 +295,15 @@ func TestReadProperties(t *testing.T) {
 		// Optionally disable debugging
 		debugMode = s.debug
 		if debugMode {
-			debugLevel = 10
+			setDebugLevel(10)
 		} else {
-			debugLevel = 0
+			setDebugLevel(0)
 		}
 
 		// Read properties
 		r := NewReader(buf)
-		props := ReadProperties(r, serializer)
+		props, err := ReadProperties(r, serializer)
+		assert.NoError(err)
 		assert.Equal(s.expectCount, len(props.KV))
 
 		for k, v := range s.expectKeys {
@@ -313,3 +316,54 @@ func TestReadProperties(t *testing.T) {
 		assert.True(r.remBits() < 8)
 	}
 }
+
+func TestReadPropertiesConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	m := &dota.CDemoSendTables{}
+	if err := proto.Unmarshal(_read_fixture("send_tables/1731962898.pbmsg"), m); err != nil {
+		panic(err)
+	}
+
+	tables := []string{"CBaseAnimating", "CDOTA_BaseNPC", "CDOTAPlayer", "CDOTATeam"}
+	bufs := make(map[string][]byte)
+	for _, name := range tables {
+		bufs[name] = _read_fixture(_sprintf("instancebaseline/1731962898_%s.rawbuf", name))
+	}
+
+	// Serializers shared by all goroutines.
+	shared, err := (&Parser{}).ParseSendTables(m, GetDefaultPropertySerializerTable())
+	assert.NoError(err)
+
+	// Read the same baselines from many goroutines, with both shared and
+	// per-goroutine serializers, as happens when running parsers in parallel.
+	var wg sync.WaitGroup
+	errs := make(chan error, 8*len(tables))
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			fs := shared
+			if i%2 == 1 {
+				var err error
+				if fs, err = (&Parser{}).ParseSendTables(m, GetDefaultPropertySerializerTable()); err != nil {
+					errs <- err
+					return
+				}
+			}
+
+			for _, name := range tables {
+				if _, err := ReadProperties(NewReader(bufs[name]), fs.Serializers[name][0]); err != nil {
+					errs <- err
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(err)
+	}
+}
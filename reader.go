@@ -11,15 +11,27 @@ var (
 )
 
 // A reader holds a buffer and performs read operations against it.
+//
+// Reading past the end of the buffer doesn't panic. Instead the first error
+// is recorded and all subsequent reads return zero values, so callers should
+// check the error once they've finished reading a structure.
 type Reader struct {
 	buf  []byte
 	size int
 	pos  int
+	err  error
 }
 
 // Creates a new reader object with a given buffer.
 func NewReader(buf []byte) *Reader {
-	return &Reader{buf, len(buf) * 8, 0}
+	return &Reader{buf, len(buf) * 8, 0, nil}
+}
+
+// Records an error with printf syntax, keeping only the first one.
+func (r *Reader) fail(format string, args ...interface{}) {
+	if r.err == nil {
+		r.err = _errorf(format, args...)
+	}
 }
 
 // Calculates our byte position.
@@ -40,7 +52,8 @@ func (r *Reader) remBytes() int {
 // Seeks a given number of bits (may be negative).
 func (r *Reader) seekBits(n int) {
 	if r.pos+n >= r.size || r.pos+n < 0 {
-		_panicf("seek overflow: %d bits requested, only %d remaining", n, r.size-r.pos)
+		r.fail("seek overflow: %d bits requested, only %d remaining", n, r.size-r.pos)
+		return
 	}
 	r.pos += n
 }
@@ -50,34 +63,42 @@ func (r *Reader) seekBytes(n int) {
 	r.seekBits(n * 8)
 }
 
+// Reads the bytes of a fixed width value, yielding zeroes if the read fails.
+func (r *Reader) readFixedBytes(n int) []byte {
+	if buf := r.readBytes(n); buf != nil {
+		return buf
+	}
+	return make([]byte, n)
+}
+
 // Reads a little-endian uint16.
 func (r *Reader) readLeUint16() uint16 {
-	return littleEndian.Uint16(r.readBytes(2))
+	return littleEndian.Uint16(r.readFixedBytes(2))
 }
 
 // Reads a little-endian uint32.
 func (r *Reader) readLeUint32() uint32 {
-	return littleEndian.Uint32(r.readBytes(4))
+	return littleEndian.Uint32(r.readFixedBytes(4))
 }
 
 // Reads a little-endian uint64.
 func (r *Reader) readLeUint64() uint64 {
-	return littleEndian.Uint64(r.readBytes(8))
+	return littleEndian.Uint64(r.readFixedBytes(8))
 }
 
 // Reads a big-endian uint16.
 func (r *Reader) readBeUint16() uint16 {
-	return bigEndian.Uint16(r.readBytes(2))
+	return bigEndian.Uint16(r.readFixedBytes(2))
 }
 
 // Reads a big-endian uint32.
 func (r *Reader) readBeUint32() uint32 {
-	return bigEndian.Uint32(r.readBytes(4))
+	return bigEndian.Uint32(r.readFixedBytes(4))
 }
 
 // Reads a big-endian uint64.
 func (r *Reader) readBeUint64() uint64 {
-	return bigEndian.Uint64(r.readBytes(8))
+	return bigEndian.Uint64(r.readFixedBytes(8))
 }
 
 // Reads an unsigned 32-bit varint.
@@ -114,7 +135,8 @@ func (r *Reader) readVarUint64() uint64 {
 		b := r.readByte()
 		if b < 0x80 {
 			if i > 9 || i == 9 && b > 1 {
-				_panicf("read overflow: varint overflows uint64")
+				r.fail("read overflow: varint overflows uint64")
+				return 0
 			}
 			return x | uint64(b)<<s
 		}
@@ -136,7 +158,8 @@ func (r *Reader) readVarInt64() int64 {
 // Reads a boolean value.
 func (r *Reader) readBoolean() bool {
 	if r.remBits() < 1 {
-		_panicf("read overflow: no bits left")
+		r.fail("read overflow: no bits left")
+		return false
 	}
 
 	b := r.buf[r.pos/8]&(1<<uint(r.pos%8)) != 0
@@ -208,8 +231,9 @@ func (r *Reader) readByte() byte {
 
 // Reads the given number of bytes from the buffer.
 func (r *Reader) readBytes(n int) []byte {
-	if r.remBits() < (n * 8) {
-		_panicf("read overflow: %d bits requested, only %d remaining", n*8, r.remBits())
+	if n < 0 || r.remBits() < (n*8) {
+		r.fail("read overflow: %d bits requested, only %d remaining", n*8, r.remBits())
+		return nil
 	}
 
 	// Fast path if our position is byte-aligned.
@@ -227,6 +251,15 @@ func (r *Reader) readBytes(n int) []byte {
 	return buf
 }
 
+// Skips a given number of bytes, allowing the end of the buffer to be reached.
+func (r *Reader) skipBytes(n int) {
+	if n < 0 || r.remBits() < (n*8) {
+		r.fail("skip overflow: %d bits requested, only %d remaining", n*8, r.remBits())
+		return
+	}
+	r.pos += n * 8
+}
+
 // Reads a string of a given length.
 func (r *Reader) readStringN(n int) string {
 	return string(r.readBytes(n))
@@ -364,11 +397,13 @@ func (r *Reader) readBitsAsBytes(n int) []byte {
 // Read bits of a given length as a uint, may or may not be byte-aligned.
 func (r *Reader) readBits(n int) uint32 {
 	if r.remBits() < n {
-		_panicf("read overflow: %d bits requested, only %d remaining", n, r.remBits())
+		r.fail("read overflow: %d bits requested, only %d remaining", n, r.remBits())
+		return 0
 	}
 
 	if n > 32 {
-		_panicf("invalid read: %d is greater than maximum read of 32 bits", n)
+		r.fail("invalid read: %d is greater than maximum read of 32 bits", n)
+		return 0
 	}
 
 	bitOffset := r.pos % 8
@@ -152,6 +152,22 @@ func BenchmarkReadVarUint64(b *testing.B) {
 	b.ReportAllocs()
 }
 
+func TestReaderOverflow(t *testing.T) {
+	assert := assert.New(t)
+
+	r := NewReader([]byte{0xFF, 0x01})
+	assert.Equal(uint32(0x01FF), r.readBits(16))
+	assert.NoError(r.err)
+
+	// Reading past the end records an error and yields zero values.
+	assert.Equal(uint32(0), r.readBits(1))
+	assert.Error(r.err)
+	assert.Nil(r.readBytes(4))
+	assert.Equal(uint32(0), r.readLeUint32())
+	assert.Equal("", r.readString())
+	assert.Equal(16, r.pos)
+}
+
 func BenchmarkReadBytesAligned(b *testing.B) {
 	r := NewReader(makeBuffer(1024))
 
@@ -0,0 +1,78 @@
+package manta
+
+import (
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// The demo and packet type ids of each message type, for generic callbacks.
+var (
+	demoMessageIds   = messageIdsByType(demoMessageTypes)
+	packetMessageIds = messageIdsByType(packetMessageTypes)
+)
+
+// Builds a reverse lookup of message type to type ids from a registry.
+func messageIdsByType(registry map[int32]func() proto.Message) map[reflect.Type][]int32 {
+	ids := make(map[reflect.Type][]int32)
+	for id, fn := range registry {
+		t := reflect.TypeOf(fn())
+		ids[t] = append(ids[t], id)
+	}
+	return ids
+}
+
+// Registers a callback for messages of type T, whether they appear as outer
+// messages or as inner packets. For example:
+//
+//	manta.On(p, func(m *dota.CDemoFileInfo) error { ... })
+//
+// Returns an error if T isn't a message type that appears in replays.
+func On[T proto.Message](p *Parser, fn func(T) error) error {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	demoIds, packetIds := demoMessageIds[t], packetMessageIds[t]
+	if demoIds == nil && packetIds == nil {
+		return _errorf("%s is not a replay message type", t)
+	}
+
+	cb := func(msg proto.Message) error {
+		return fn(msg.(T))
+	}
+
+	c := p.Callbacks
+	if demoIds != nil && c.onDemoMessage == nil {
+		c.onDemoMessage = make(map[int32][]func(proto.Message) error)
+	}
+	if packetIds != nil && c.onPacketMessage == nil {
+		c.onPacketMessage = make(map[int32][]func(proto.Message) error)
+	}
+	for _, id := range demoIds {
+		c.onDemoMessage[id] = append(c.onDemoMessage[id], cb)
+	}
+	for _, id := range packetIds {
+		c.onPacketMessage[id] = append(c.onPacketMessage[id], cb)
+	}
+
+	return nil
+}
+
+// Registers a callback for every message with a known type, outer messages
+// and inner packets alike, along with the tick it was received on.
+func (p *Parser) OnMessage(fn func(tick uint32, msg proto.Message) error) {
+	p.Callbacks.onMessage = append(p.Callbacks.onMessage, fn)
+}
+
+// Calls the generic callbacks for a message after its typed callbacks.
+func (p *Parser) callMessage(c *Callbacks, cbs []func(proto.Message) error, msg proto.Message) error {
+	for _, fn := range cbs {
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+	for _, fn := range c.onMessage {
+		if err := fn(p.Tick, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
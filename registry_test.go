@@ -0,0 +1,101 @@
+package manta
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeTestReplayMessages() []byte {
+	tick, _ := proto.Marshal(&dota.CNETMsg_Tick{Tick: proto.Uint32(7)})
+
+	return makeTestReplay(
+		testOuterMessage{dota.EDemoCommands_DEM_FileHeader, 4294967295, &dota.CDemoFileHeader{
+			DemoFileStamp: proto.String("PBDEMS2\000"),
+		}},
+		testOuterMessage{dota.EDemoCommands_DEM_Packet, 7, &dota.CDemoPacket{
+			Data: makeTestInnerPackets(testInnerPacket{4, tick}),
+		}},
+		testOuterMessage{dota.EDemoCommands_DEM_FileInfo, 8, &dota.CDemoFileInfo{PlaybackTicks: proto.Int32(8)}},
+	)
+}
+
+func TestOn(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMessages())
+	assert.NoError(err)
+
+	var playbackTicks int32
+	assert.NoError(On(parser, func(m *dota.CDemoFileInfo) error {
+		playbackTicks = m.GetPlaybackTicks()
+		return nil
+	}))
+
+	ticks := make([]uint32, 0)
+	assert.NoError(On(parser, func(m *dota.CNETMsg_Tick) error {
+		ticks = append(ticks, m.GetTick())
+		return nil
+	}))
+
+	// Typed callbacks are still called for the same messages.
+	fileInfos := 0
+	parser.Callbacks.OnCDemoFileInfo(func(m *dota.CDemoFileInfo) error {
+		fileInfos += 1
+		return nil
+	})
+
+	assert.NoError(parser.Start())
+	assert.Equal(int32(8), playbackTicks)
+	assert.Equal([]uint32{7}, ticks)
+	assert.Equal(1, fileInfos)
+}
+
+func TestOnNotReplayMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMessages())
+	assert.NoError(err)
+
+	assert.Error(On(parser, func(m *dota.CDemoStringTablesItemsT) error { return nil }))
+}
+
+func TestOnMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMessages())
+	assert.NoError(err)
+
+	msgs := make([]string, 0)
+	parser.OnMessage(func(tick uint32, msg proto.Message) error {
+		msgs = append(msgs, fmt.Sprintf("%d %T", tick, msg))
+		return nil
+	})
+
+	// Inner packets are dispatched by an internal callback for the packet, so
+	// they're seen before the packet itself.
+	assert.NoError(parser.Start())
+	assert.Equal([]string{
+		"0 *dota.CDemoFileHeader",
+		"7 *dota.CNETMsg_Tick",
+		"7 *dota.CDemoPacket",
+		"8 *dota.CDemoFileInfo",
+	}, msgs)
+}
+
+func TestOnMessageError(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMessages())
+	assert.NoError(err)
+
+	errStop := fmt.Errorf("stop")
+	assert.NoError(On(parser, func(m *dota.CNETMsg_Tick) error {
+		return errStop
+	}))
+
+	assert.ErrorIs(parser.Start(), errStop)
+}
@@ -0,0 +1,157 @@
+package manta
+
+import (
+	"time"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/golang/protobuf/proto"
+)
+
+// Summary information about a replay, taken from its CDemoFileHeader and
+// CDemoFileInfo messages.
+type ReplayInfo struct {
+	MatchId       uint32
+	GameMode      int32
+	GameWinner    int32
+	EndTime       uint32
+	Duration      time.Duration
+	PlaybackTicks int32
+	ServerName    string
+	Players       []*ReplayPlayer
+
+	// The messages the information was taken from.
+	FileHeader *dota.CDemoFileHeader
+	FileInfo   *dota.CDemoFileInfo
+}
+
+// Describes a player in a replay.
+type ReplayPlayer struct {
+	Name         string
+	SteamId      uint64
+	HeroName     string
+	Team         int32
+	IsFakeClient bool
+}
+
+// Read summary information from a replay file without parsing it in full.
+// The file header is read from the start of the replay, then the file info is
+// read from the offset given in the replay header, which is near the end of
+// the file. Uncompressed replays are seeked through directly, compressed ones
+// are decompressed up to the file info without being decoded. If the offset
+// is missing or doesn't point at the file info, the replay is scanned for it
+// instead.
+func ReadReplayInfo(path string) (*ReplayInfo, error) {
+	parser, err := NewParserFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer parser.close()
+
+	return parser.readReplayInfo()
+}
+
+// Reads summary information from a replay that hasn't been started.
+func (p *Parser) readReplayInfo() (*ReplayInfo, error) {
+	// The file header is the first message.
+	header := &dota.CDemoFileHeader{}
+	if err := p.readOuterMessageAs(dota.EDemoCommands_DEM_FileHeader, header); err != nil {
+		return nil, err
+	}
+
+	// Jump to the file info if we know where it is, otherwise scan forward
+	// for it from just after the file header.
+	start := p.stream.pos
+	info := &dota.CDemoFileInfo{}
+	if !p.readFileInfoAt(p.fileInfoOffset, info) {
+		if p.stream.pos != start {
+			if err := p.seekTo(start); err != nil {
+				return nil, err
+			}
+		}
+
+		info.Reset()
+		if err := p.readOuterMessageAs(dota.EDemoCommands_DEM_FileInfo, info); err != nil {
+			return nil, err
+		}
+	}
+
+	dotaInfo := info.GetGameInfo().GetDota()
+	ri := &ReplayInfo{
+		MatchId:       dotaInfo.GetMatchId(),
+		GameMode:      dotaInfo.GetGameMode(),
+		GameWinner:    dotaInfo.GetGameWinner(),
+		EndTime:       dotaInfo.GetEndTime(),
+		Duration:      time.Duration(float64(info.GetPlaybackTime()) * float64(time.Second)),
+		PlaybackTicks: info.GetPlaybackTicks(),
+		ServerName:    header.GetServerName(),
+		Players:       make([]*ReplayPlayer, 0),
+		FileHeader:    header,
+		FileInfo:      info,
+	}
+
+	for _, pi := range dotaInfo.GetPlayerInfo() {
+		ri.Players = append(ri.Players, &ReplayPlayer{
+			Name:         pi.GetPlayerName(),
+			SteamId:      pi.GetSteamid(),
+			HeroName:     pi.GetHeroName(),
+			Team:         pi.GetGameTeam(),
+			IsFakeClient: pi.GetIsFakeClient(),
+		})
+	}
+
+	return ri, nil
+}
+
+// Reads the CDemoFileInfo at the given offset into info. Returns false if the
+// replay isn't seekable, or if the offset is unset, past the end of the replay
+// or doesn't point at a file info, in which case the parser may have moved.
+func (p *Parser) readFileInfoAt(offset int64, info *dota.CDemoFileInfo) bool {
+	if p.seeker == nil || offset <= p.stream.pos {
+		return false
+	}
+
+	if err := p.seekTo(offset); err != nil {
+		return false
+	}
+
+	h, err := p.readOuterHeader()
+	if err != nil || h.typeId != int32(dota.EDemoCommands_DEM_FileInfo) {
+		return false
+	}
+
+	data, err := p.readOuterData(h)
+	if err != nil {
+		return false
+	}
+
+	return proto.Unmarshal(data, info) == nil
+}
+
+// Reads outer messages until one of the given type is found, unmarshaling it
+// into msg. Messages of other types are skipped without being decoded.
+func (p *Parser) readOuterMessageAs(t dota.EDemoCommands, msg proto.Message) error {
+	for {
+		offset := p.stream.pos
+
+		h, err := p.readOuterHeader()
+		if err != nil {
+			return &DecodeError{Tick: p.Tick, MessageType: int32(dota.EDemoCommands_DEM_Error), Offset: offset, Cause: err}
+		}
+
+		if h.typeId != int32(t) {
+			if err := p.stream.skip(h.size); err != nil {
+				return &DecodeError{Tick: h.tick, MessageType: h.typeId, Offset: offset, Cause: truncated(err)}
+			}
+			continue
+		}
+
+		data, err := p.readOuterData(h)
+		if err == nil {
+			err = proto.Unmarshal(data, msg)
+		}
+		if err != nil {
+			return &DecodeError{Tick: h.tick, MessageType: h.typeId, Offset: offset, Cause: err}
+		}
+		return nil
+	}
+}
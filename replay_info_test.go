@@ -0,0 +1,131 @@
+package manta
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// The file header of the replays used to test reading replay info.
+func makeTestInfoFileHeader() testOuterMessage {
+	return testOuterMessage{dota.EDemoCommands_DEM_FileHeader, 4294967295, &dota.CDemoFileHeader{
+		DemoFileStamp: proto.String("PBDEMS2\000"),
+		ServerName:    proto.String("test server"),
+	}}
+}
+
+// A replay with a header pointing at the file info near the end.
+func makeTestReplayWithInfo() []byte {
+	msgs := []testOuterMessage{
+		makeTestInfoFileHeader(),
+		{dota.EDemoCommands_DEM_Packet, 10, &dota.CDemoPacket{Data: make([]byte, 1024)}},
+		{dota.EDemoCommands_DEM_FileInfo, 100, &dota.CDemoFileInfo{
+			PlaybackTime:  proto.Float32(90.5),
+			PlaybackTicks: proto.Int32(100),
+			GameInfo: &dota.CGameInfo{
+				Dota: &dota.CGameInfo_CDotaGameInfo{
+					MatchId:    proto.Uint32(1234),
+					GameMode:   proto.Int32(22),
+					GameWinner: proto.Int32(2),
+					PlayerInfo: []*dota.CGameInfo_CDotaGameInfo_CPlayerInfo{
+						{PlayerName: proto.String("a"), HeroName: proto.String("npc_dota_hero_axe"), Steamid: proto.Uint64(1), GameTeam: proto.Int32(2)},
+						{PlayerName: proto.String("b"), HeroName: proto.String("npc_dota_hero_lina"), Steamid: proto.Uint64(2), GameTeam: proto.Int32(3)},
+					},
+				},
+			},
+		}},
+		{dota.EDemoCommands_DEM_Stop, 100, &dota.CDemoStop{}},
+	}
+
+	buf := makeTestReplay(msgs...)
+	offset := len(makeTestReplay(msgs[:2]...))
+	binary.LittleEndian.PutUint32(buf[8:], uint32(offset))
+	return buf
+}
+
+func TestReadReplayInfo(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := ioutil.TempDir("", "manta")
+	assert.NoError(err)
+	defer os.RemoveAll(dir)
+
+	raw := makeTestReplayWithInfo()
+
+	gz := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(gz)
+	gw.Write(raw)
+	gw.Close()
+
+	// Without an offset, the file info is found by skipping messages.
+	noOffset := append([]byte{}, raw...)
+	binary.LittleEndian.PutUint32(noOffset[8:], 0)
+
+	// Bogus offsets fall back to skipping messages too, whether they're past
+	// the end of the replay, at another message or partway through one.
+	pastEnd := append([]byte{}, raw...)
+	binary.LittleEndian.PutUint32(pastEnd[8:], uint32(len(raw)+10))
+	atPacket := append([]byte{}, raw...)
+	binary.LittleEndian.PutUint32(atPacket[8:], uint32(len(makeTestReplay(makeTestInfoFileHeader()))))
+	midPacket := append([]byte{}, raw...)
+	binary.LittleEndian.PutUint32(midPacket[8:], 100)
+
+	badOffsetGz := bytes.NewBuffer(nil)
+	gw = gzip.NewWriter(badOffsetGz)
+	gw.Write(pastEnd)
+	gw.Close()
+
+	// A replay without a file info is reported.
+	noInfo := makeTestReplay(
+		makeTestInfoFileHeader(),
+		testOuterMessage{dota.EDemoCommands_DEM_Stop, 100, &dota.CDemoStop{}},
+	)
+
+	scenarios := []struct {
+		name string
+		buf  []byte
+		ok   bool
+	}{
+		{"replay.dem", raw, true},
+		{"replay.dem.gz", gz.Bytes(), true},
+		{"nooffset.dem", noOffset, true},
+		{"pastend.dem", pastEnd, true},
+		{"atpacket.dem", atPacket, true},
+		{"midpacket.dem", midPacket, true},
+		{"badoffset.dem.gz", badOffsetGz.Bytes(), true},
+		{"noinfo.dem", noInfo, false},
+	}
+
+	for _, s := range scenarios {
+		path := filepath.Join(dir, s.name)
+		assert.NoError(ioutil.WriteFile(path, s.buf, 0644))
+
+		info, err := ReadReplayInfo(path)
+		if !s.ok {
+			assert.Error(err, s.name)
+			continue
+		}
+		if !assert.NoError(err, s.name) {
+			continue
+		}
+
+		assert.Equal(uint32(1234), info.MatchId, s.name)
+		assert.Equal(int32(22), info.GameMode, s.name)
+		assert.Equal(int32(2), info.GameWinner, s.name)
+		assert.Equal(90500*time.Millisecond, info.Duration, s.name)
+		assert.Equal(int32(100), info.PlaybackTicks, s.name)
+		assert.Equal("test server", info.ServerName, s.name)
+		if assert.Len(info.Players, 2, s.name) {
+			assert.Equal(&ReplayPlayer{"b", 2, "npc_dota_hero_lina", 3, false}, info.Players[1], s.name)
+		}
+	}
+}
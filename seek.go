@@ -0,0 +1,206 @@
+package manta
+
+import (
+	"io"
+	"sort"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/golang/protobuf/proto"
+)
+
+// The size of the replay header, being the magic and two offsets. The first
+// message follows it.
+const replayHeaderSize = 16
+
+// Records the position of a CDemoFullPacket within the replay. Full packets
+// contain complete snapshots of the string tables and entities, so they can
+// be used as starting points when seeking.
+type IndexEntry struct {
+	Tick   uint32
+	Offset int64
+}
+
+// An index of the positions within a replay that can be seeked to.
+type ReplayIndex struct {
+	// The offset of the first message following the signon messages. The
+	// signon messages set up the state that full packets build upon, such as
+	// send tables, class info and string tables.
+	SignonEnd int64
+
+	// The full packets in the replay, in the order they appear.
+	FullPackets []IndexEntry
+}
+
+// Returns the last full packet at or before the given tick. Check the bool.
+func (idx *ReplayIndex) lookup(tick uint32) (IndexEntry, bool) {
+	i := sort.Search(len(idx.FullPackets), func(i int) bool {
+		return idx.FullPackets[i].Tick > tick
+	})
+	if i == 0 {
+		return IndexEntry{}, false
+	}
+	return idx.FullPackets[i-1], true
+}
+
+// Scan the whole replay for full packets, recording their offsets for use by
+// SeekToTick(). Messages are skipped over without being decoded and no
+// callbacks are invoked. The scan starts from the first message regardless of
+// how far parsing has got, and the parser is left at the position it was in
+// before the scan. Requires a seekable replay, see ErrNotSeekable.
+func (p *Parser) BuildIndex() (*ReplayIndex, error) {
+	if p.seeker == nil {
+		return nil, ErrNotSeekable
+	}
+
+	start := p.stream.pos
+	idx := &ReplayIndex{}
+
+	if err := p.seekTo(replayHeaderSize); err != nil {
+		return nil, err
+	}
+
+	for !p.stream.atEOF() {
+		offset := p.stream.pos
+
+		h, err := p.readOuterHeader()
+		if err == nil {
+			err = truncated(p.stream.skip(h.size))
+		}
+		if err != nil {
+			if err == ErrTruncatedReplay && p.AllowTruncated {
+				break
+			}
+			return nil, &DecodeError{Tick: p.Tick, MessageType: int32(dota.EDemoCommands_DEM_Error), Offset: offset, Cause: err}
+		}
+
+		switch dota.EDemoCommands(h.typeId) {
+		case dota.EDemoCommands_DEM_SyncTick:
+			if idx.SignonEnd == 0 {
+				idx.SignonEnd = p.stream.pos
+			}
+
+		case dota.EDemoCommands_DEM_FullPacket:
+			// Without a sync tick, signon ends at the first full packet.
+			if idx.SignonEnd == 0 {
+				idx.SignonEnd = offset
+			}
+			idx.FullPackets = append(idx.FullPackets, IndexEntry{h.tick, offset})
+		}
+	}
+
+	if err := p.seekTo(start); err != nil {
+		return nil, err
+	}
+
+	p.index = idx
+	return idx, nil
+}
+
+// Position the parser at the given tick, so that the next call to Start()
+// continues with the first message after it. String tables and entities are
+// restored from the last full packet at or before the tick, then messages
+// between the full packet and the tick are processed. Callbacks are invoked
+// for the messages processed along the way, including the signon messages if
+// they haven't been processed yet. Entities discarded when restoring a full
+// packet are not reported to entity handlers. Builds an index with
+// BuildIndex() if one hasn't been built yet.
+func (p *Parser) SeekToTick(tick uint32) error {
+	if p.index == nil {
+		if _, err := p.BuildIndex(); err != nil {
+			return err
+		}
+	}
+
+	// Full packets depend on state from the signon messages, so make sure
+	// those have been processed.
+	for p.stream.pos < p.index.SignonEnd && !p.stream.atEOF() {
+		offset := p.stream.pos
+		msg, err := p.readOuterMessage()
+		if err != nil {
+			return p.readError(err, offset)
+		}
+		if err := p.processOuterMessage(msg, offset); err != nil {
+			return err
+		}
+	}
+
+	// Restore from a full packet if doing so gets us closer to the tick, or
+	// if the tick is behind us.
+	e, ok := p.index.lookup(tick)
+	switch {
+	case ok && (e.Offset > p.stream.pos || tick < p.Tick):
+		if err := p.restoreFullPacket(e); err != nil {
+			return err
+		}
+
+	case tick < p.Tick:
+		return _errorf("unable to seek back to tick %d: no full packet at or before it", tick)
+	}
+
+	// Play forward up to the tick, stopping at the first message after it.
+	for !p.stream.atEOF() {
+		offset := p.stream.pos
+		msg, err := p.readOuterMessage()
+		if err != nil {
+			return p.readError(err, offset)
+		}
+
+		if msg.tick > tick {
+			return p.seekTo(offset)
+		}
+
+		if err := p.processOuterMessage(msg, offset); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Restores string tables and entities from the given full packet, leaving
+// the parser positioned after it.
+func (p *Parser) restoreFullPacket(e IndexEntry) error {
+	if err := p.seekTo(e.Offset); err != nil {
+		return err
+	}
+
+	msg, err := p.readOuterMessage()
+	if err != nil {
+		return p.readError(err, e.Offset)
+	}
+
+	return p.applyFullPacket(msg, e.Offset)
+}
+
+// Processes a CDemoFullPacket read from the given offset, replacing the
+// current string tables and entities with the snapshot it contains.
+func (p *Parser) applyFullPacket(msg *outerMessage, offset int64) error {
+	m := &dota.CDemoFullPacket{}
+	if err := proto.Unmarshal(msg.data, m); err != nil {
+		return &DecodeError{Tick: msg.tick, MessageType: msg.typeId, Offset: offset, Cause: err}
+	}
+
+	if err := p.restoreStringTables(m.GetStringTable()); err != nil {
+		return &DecodeError{Tick: msg.tick, MessageType: msg.typeId, Offset: offset, Cause: err}
+	}
+
+	// Discard the current entities. Resetting the count of full updates makes
+	// the full update in the packet take the create path rather than being
+	// resynced against the discarded entities, and resets the entity
+	// baselines, which are relative to the first full update.
+	p.PacketEntities = make(map[int32]*PacketEntity)
+	p.packetEntityFullPackets = 0
+
+	return p.processOuterMessage(msg, offset)
+}
+
+// Reposition the stream at the given offset, discarding anything buffered.
+func (p *Parser) seekTo(offset int64) error {
+	if _, err := p.seeker.Seek(p.seekBase+offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	p.stream = newStream(p.seeker)
+	p.stream.pos = offset
+	return nil
+}
@@ -0,0 +1,176 @@
+package manta
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// A full packet containing a snapshot of the "test" string table.
+func makeTestFullPacket(keys ...string) *dota.CDemoFullPacket {
+	items := make([]*dota.CDemoStringTablesItemsT, 0)
+	for _, k := range keys {
+		items = append(items, &dota.CDemoStringTablesItemsT{Str: proto.String(k)})
+	}
+
+	return &dota.CDemoFullPacket{
+		StringTable: &dota.CDemoStringTables{
+			Tables: []*dota.CDemoStringTablesTableT{
+				{TableName: proto.String("test"), Items: items},
+			},
+		},
+	}
+}
+
+// A replay with full packets at ticks 10 and 30 and packets in between.
+func makeTestReplaySeekable() []byte {
+	return makeTestReplay(
+		testOuterMessage{dota.EDemoCommands_DEM_FileHeader, 4294967295, &dota.CDemoFileHeader{
+			DemoFileStamp: proto.String("PBDEMS2\000"),
+		}},
+		testOuterMessage{dota.EDemoCommands_DEM_SyncTick, 0, &dota.CDemoSyncTick{}},
+		testOuterMessage{dota.EDemoCommands_DEM_FullPacket, 10, makeTestFullPacket("a")},
+		testOuterMessage{dota.EDemoCommands_DEM_Packet, 20, &dota.CDemoPacket{}},
+		testOuterMessage{dota.EDemoCommands_DEM_FullPacket, 30, makeTestFullPacket("b", "c")},
+		testOuterMessage{dota.EDemoCommands_DEM_Packet, 40, &dota.CDemoPacket{}},
+		testOuterMessage{dota.EDemoCommands_DEM_Stop, 40, &dota.CDemoStop{}},
+	)
+}
+
+// Creates a parser with a "test" string table that full packets restore,
+// recording the ticks of packets processed.
+func newTestSeekParser(t *testing.T, r io.Reader) (*Parser, *[]uint32) {
+	parser, err := NewStreamParser(r)
+	assert.NoError(t, err)
+
+	parser.StringTables.Tables[0] = &StringTable{name: "test", Items: make(map[int32]*StringTableItem)}
+	parser.StringTables.NameIndex["test"] = 0
+
+	ticks := make([]uint32, 0)
+	parser.Callbacks.OnCDemoPacket(func(m *dota.CDemoPacket) error {
+		ticks = append(ticks, parser.Tick)
+		return nil
+	})
+
+	return parser, &ticks
+}
+
+func TestBuildIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, ticks := newTestSeekParser(t, bytes.NewReader(makeTestReplaySeekable()))
+
+	idx, err := parser.BuildIndex()
+	assert.NoError(err)
+	assert.True(idx.SignonEnd > 16)
+	if assert.Len(idx.FullPackets, 2) {
+		assert.Equal(uint32(10), idx.FullPackets[0].Tick)
+		assert.Equal(uint32(30), idx.FullPackets[1].Tick)
+		assert.True(idx.FullPackets[0].Offset >= idx.SignonEnd)
+		assert.True(idx.FullPackets[1].Offset > idx.FullPackets[0].Offset)
+	}
+
+	// Building the index doesn't affect parsing from the start.
+	assert.NoError(parser.Start())
+	assert.Equal([]uint32{20, 40}, *ticks)
+}
+
+func TestSeekToTick(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, ticks := newTestSeekParser(t, bytes.NewReader(makeTestReplaySeekable()))
+	table, _ := parser.StringTables.GetTableByName("test")
+
+	// Seek forward, restoring from the second full packet.
+	assert.NoError(parser.SeekToTick(35))
+	assert.Equal(uint32(30), parser.Tick)
+	assert.Len(table.Items, 2)
+	assert.Equal("b", table.GetItem(0).Key)
+	assert.Empty(*ticks)
+
+	// Seek back, restoring from the first full packet and playing forward.
+	assert.NoError(parser.SeekToTick(25))
+	assert.Equal(uint32(20), parser.Tick)
+	assert.Len(table.Items, 1)
+	assert.Equal("a", table.GetItem(0).Key)
+	assert.Equal([]uint32{20}, *ticks)
+
+	// Parsing continues after the tick.
+	assert.NoError(parser.Start())
+	assert.Equal([]uint32{20, 40}, *ticks)
+}
+
+func TestSeekToTickAfterStart(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, ticks := newTestSeekParser(t, bytes.NewReader(makeTestReplaySeekable()))
+	table, _ := parser.StringTables.GetTableByName("test")
+
+	// Parse past the first full packet before the index is built.
+	parser.Callbacks.OnCDemoPacket(func(m *dota.CDemoPacket) error {
+		if parser.Tick >= 20 {
+			parser.Stop()
+		}
+		return nil
+	})
+	assert.NoError(parser.Start())
+	assert.Equal([]uint32{20}, *ticks)
+
+	// The index covers the full packet already parsed, so seeking back to it
+	// works.
+	assert.NoError(parser.SeekToTick(15))
+	assert.Equal(uint32(10), parser.Tick)
+	assert.Len(table.Items, 1)
+	assert.Equal("a", table.GetItem(0).Key)
+}
+
+func TestSeekToTickNotSeekable(t *testing.T) {
+	assert := assert.New(t)
+
+	gz := bytes.NewBuffer(nil)
+	gw := gzip.NewWriter(gz)
+	gw.Write(makeTestReplaySeekable())
+	gw.Close()
+
+	parser, err := NewParser(gz.Bytes())
+	assert.NoError(err)
+	assert.Equal(ErrNotSeekable, parser.SeekToTick(10))
+}
+
+func TestStartRange(t *testing.T) {
+	assert := assert.New(t)
+
+	scenarios := []struct {
+		from, to uint32
+		expected []uint32
+	}{
+		{0, 100, []uint32{20, 40}},
+		{15, 25, []uint32{20}},
+		{20, 20, []uint32{20}},
+		{25, 100, []uint32{40}},
+		{0, 15, []uint32{}},
+	}
+
+	for _, s := range scenarios {
+		// Seekable, restoring state from a full packet.
+		parser, ticks := newTestSeekParser(t, bytes.NewReader(makeTestReplaySeekable()))
+		headers := 0
+		parser.Callbacks.OnCDemoFileHeader(func(m *dota.CDemoFileHeader) error {
+			headers += 1
+			return nil
+		})
+		assert.NoError(parser.StartRange(s.from, s.to))
+		assert.Equal(s.expected, *ticks, "seekable %d-%d", s.from, s.to)
+		assert.Equal(s.from == 0, headers == 1, "seekable %d-%d", s.from, s.to)
+
+		// Not seekable, skipping messages before the range.
+		parser, ticks = newTestSeekParser(t, bytes.NewBuffer(makeTestReplaySeekable()))
+		assert.NoError(parser.StartRange(s.from, s.to))
+		assert.Equal(s.expected, *ticks, "stream %d-%d", s.from, s.to)
+	}
+}
@@ -0,0 +1,94 @@
+package manta
+
+import (
+	"reflect"
+)
+
+// A copy of the state of an entity at a tick. Unlike a PacketEntity, it's
+// never changed by the parser, so it's safe to keep after a callback returns.
+type EntitySnapshot struct {
+	Tick      uint32
+	Index     int32
+	ClassId   int32
+	ClassName string
+	Serial    int32
+	Active    bool
+
+	// The effective properties of the entity, with baseline values resolved.
+	Properties *Properties
+}
+
+// Creates a snapshot of the current state of the entity.
+func (pe *PacketEntity) Snapshot() *EntitySnapshot {
+	s := &EntitySnapshot{
+		Index:      pe.Index,
+		ClassId:    pe.ClassId,
+		ClassName:  pe.ClassName,
+		Serial:     pe.Serial,
+		Active:     pe.Active,
+		Properties: NewProperties(),
+	}
+
+	if pe.parser != nil {
+		s.Tick = pe.parser.Tick
+	}
+
+	for k, v := range pe.AllProperties() {
+		s.Properties.KV[k] = copyPropertyValue(v)
+	}
+
+	return s
+}
+
+// Copies a property value, so that slices such as vectors aren't shared.
+func copyPropertyValue(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.IsNil() {
+		return v
+	}
+
+	c := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+	reflect.Copy(c, rv)
+	return c.Interface()
+}
+
+// A snapshot of entities at a tick.
+type EntitiesSnapshot struct {
+	Tick uint32
+
+	// The entities by index.
+	Entities map[int32]*EntitySnapshot
+}
+
+// Creates a snapshot of the entities accepted by filter, or all entities if
+// filter is nil. As entity updates in a packet are applied before any
+// handlers are called, the snapshot is consistent when taken from a callback.
+func (p *Parser) SnapshotEntities(filter func(*PacketEntity) bool) *EntitiesSnapshot {
+	s := &EntitiesSnapshot{
+		Tick:     p.Tick,
+		Entities: make(map[int32]*EntitySnapshot),
+	}
+
+	for index, pe := range p.PacketEntities {
+		if filter == nil || filter(pe) {
+			s.Entities[index] = pe.Snapshot()
+		}
+	}
+
+	return s
+}
+
+// Looks up the entity in the snapshot referenced by a handle.
+func (s *EntitiesSnapshot) EntityByHandle(h uint32) (*EntitySnapshot, bool) {
+	if h == invalidEntityHandle {
+		return nil, false
+	}
+
+	index, _ := splitEntityHandle(h)
+	e, ok := s.Entities[index]
+	if !ok || !entityHandleMatches(h, e.Serial) {
+		return nil, false
+	}
+
+	return e, true
+}
@@ -0,0 +1,87 @@
+package manta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntitySnapshot(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+	parser.ClassBaselines[1].KV["m_iHealth"] = uint32(100)
+	parser.ClassBaselines[1].KV["m_vecOrigin"] = []float32{1, 2, 3}
+	parser.Tick = 10
+
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(
+		testEntityEntry{1, EntityEventType_Create, 1, 7, map[int]uint32{0: 1}},
+	)))
+
+	pe := parser.PacketEntities[1]
+	s := pe.Snapshot()
+	assert.Equal(uint32(10), s.Tick)
+	assert.Equal(int32(1), s.Index)
+	assert.Equal("CTest", s.ClassName)
+	assert.Equal(int32(7), s.Serial)
+	assert.True(s.Active)
+	assert.Equal(map[string]interface{}{
+		"m_iCurrentLevel": uint32(1),
+		"m_iHealth":       uint32(100),
+		"m_vecOrigin":     []float32{1, 2, 3},
+	}, s.Properties.KV)
+
+	// Later updates don't affect the snapshot.
+	parser.Tick = 11
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{1, EntityEventType_Update, 0, 0, map[int]uint32{0: 2, 2: 50}},
+	)))
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{1, EntityEventType_Leave, 0, 0, nil},
+	)))
+	s.Properties.KV["m_vecOrigin"].([]float32)[0] = 5
+
+	assert.Equal(uint32(10), s.Tick)
+	assert.True(s.Active)
+	assert.Equal(uint32(1), s.Properties.KV["m_iCurrentLevel"])
+	assert.Equal(uint32(100), s.Properties.KV["m_iHealth"])
+	assert.Equal([]float32{1, 2, 3}, parser.ClassBaselines[1].KV["m_vecOrigin"])
+}
+
+func TestSnapshotEntities(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, _ := newTestEntityParser(t)
+	parser.Tick = 20
+
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesFull(
+		testEntityEntry{1, EntityEventType_Create, 1, 7, map[int]uint32{0: 1}},
+		testEntityEntry{2, EntityEventType_Create, 1, 8, map[int]uint32{0: 2}},
+	)))
+
+	all := parser.SnapshotEntities(nil)
+	assert.Equal(uint32(20), all.Tick)
+	assert.Len(all.Entities, 2)
+
+	e, ok := all.EntityByHandle(uint32(2 | 8<<14))
+	assert.True(ok)
+	assert.Equal(int32(8), e.Serial)
+	_, ok = all.EntityByHandle(uint32(2 | 7<<14))
+	assert.False(ok)
+	_, ok = all.EntityByHandle(HANDLE_NONE)
+	assert.False(ok)
+
+	filtered := parser.SnapshotEntities(func(pe *PacketEntity) bool {
+		level, _ := pe.FetchUint32("m_iCurrentLevel")
+		return level > 1
+	})
+	assert.Len(filtered.Entities, 1)
+	assert.Contains(filtered.Entities, int32(2))
+
+	// Deleted entities remain in earlier snapshots.
+	assert.NoError(parser.onCSVCMsg_PacketEntities(makeTestPacketEntitiesMessage(
+		testEntityEntry{1, EntityEventType_Delete, 0, 0, nil},
+	)))
+	assert.Len(all.Entities, 2)
+	assert.Len(parser.SnapshotEntities(nil).Entities, 1)
+}
@@ -23,20 +23,22 @@ type spawnGroup struct {
 	complete      bool
 }
 
-func (sg *spawnGroup) writeFixture() {
+func (sg *spawnGroup) writeFixture() error {
 	// [id]_[isComplete]_sg_manifest.raw
 	fname := fmt.Sprintf("%d_%t_sg_manifest.raw", sg.handle, sg.complete)
 	err := ioutil.WriteFile(fname, sg.manifest, 0644)
 
 	if err != nil {
-		_panicf("Error writing spawnGroup fixture, %s", err)
+		return _errorf("Error writing spawnGroup fixture, %w", err)
 	}
+
+	return nil
 }
 
 // Parse a spawnGroup manifest
 // Format: <1 bit IsLZSSCompressed | 24 bit length | Data>
 // Data: <8 bit arrayLength | 0 | 8 bit ressourceStrings | 0 | 8 bit unkown | 0 | dataTypes * arrayLength | 0 | ... data ...>
-func (sg *spawnGroup) parse() {
+func (sg *spawnGroup) parse() error {
 	reader := NewReader(sg.manifest)
 
 	isCompressed := reader.readBoolean()
@@ -44,9 +46,13 @@ func (sg *spawnGroup) parse() {
 	data := reader.readBytes(int(size))
 
 	if isCompressed {
+		if reader.err != nil {
+			return _errorf("Error reading spawnGroup data %w", reader.err)
+		}
+
 		dataUnc, err := unlzss(data)
 		if err != nil {
-			_panicf("Error uncompressing spawnGroup data %s", err)
+			return _errorf("Error uncompressing spawnGroup data %w", err)
 		}
 
 		data = dataUnc
@@ -67,6 +73,15 @@ func (sg *spawnGroup) parse() {
 			_ = reader2.readString() // e.g. models/items/rubick/peculiar_prestidigitator_shoulders/
 		}
 	}
+
+	if reader.err != nil {
+		return _errorf("Error reading spawnGroup manifest %w", reader.err)
+	}
+	if reader2.err != nil {
+		return _errorf("Error reading spawnGroup data %w", reader2.err)
+	}
+
+	return nil
 }
 
 func (p *Parser) onCNETMsg_SpawnGroup_Load(m *dota.CNETMsg_SpawnGroup_Load) error {
@@ -85,14 +100,13 @@ func (p *Parser) onCNETMsg_SpawnGroup_Load(m *dota.CNETMsg_SpawnGroup_Load) erro
 	}
 
 	p.spawnGroups[m.GetSpawngrouphandle()] = sg
-	sg.parse()
-	return nil
+	return sg.parse()
 }
 
 func (p *Parser) onCNETMsg_SpawnGroup_ManifestUpdate(m *dota.CNETMsg_SpawnGroup_ManifestUpdate) error {
 	sg, ok := p.spawnGroups[m.GetSpawngrouphandle()]
 	if !ok {
-		_panicf("Unable to find spawngroup %d for update %d", m.GetSpawngrouphandle(), p.Tick)
+		return _errorf("Unable to find spawngroup %d for update %d", m.GetSpawngrouphandle(), p.Tick)
 	}
 
 	// Invoke the parse method, the data should be added to the spawnGroup variable
@@ -100,15 +114,13 @@ func (p *Parser) onCNETMsg_SpawnGroup_ManifestUpdate(m *dota.CNETMsg_SpawnGroup_
 
 	sg.manifest = m.GetSpawngroupmanifest()
 	sg.complete = !m.GetManifestincomplete()
-	sg.parse()
-
-	return nil
+	return sg.parse()
 }
 
 func (p *Parser) onCNETMsg_SpawnGroup_SetCreationTick(m *dota.CNETMsg_SpawnGroup_SetCreationTick) error {
 	sg, ok := p.spawnGroups[m.GetSpawngrouphandle()]
 	if !ok {
-		_panicf("Unable to find spawngroup %d for tick update", m.GetSpawngrouphandle())
+		return _errorf("Unable to find spawngroup %d for tick update", m.GetSpawngrouphandle())
 	}
 
 	sg.tickCount = m.GetTickcount()
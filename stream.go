@@ -0,0 +1,96 @@
+package manta
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// The size of the buffer used when reading outer messages from a stream.
+const streamBufferSize = 64 * 1024
+
+// A stream reads byte-aligned data from an underlying io.Reader. It is used
+// to read outer messages incrementally, so that the whole replay never needs
+// to be held in memory at once.
+type stream struct {
+	*bufio.Reader
+	pos int64
+}
+
+// Creates a new stream reading from the given io.Reader.
+func newStream(r io.Reader) *stream {
+	return &stream{bufio.NewReaderSize(r, streamBufferSize), 0}
+}
+
+// Reads the given number of bytes into a newly allocated buffer. Large reads
+// grow the buffer as data arrives, so that a corrupt size doesn't allocate
+// more memory than the input actually holds.
+func (s *stream) readBytes(n uint32) ([]byte, error) {
+	if n <= streamBufferSize {
+		buf := make([]byte, n)
+		m, err := io.ReadFull(s.Reader, buf)
+		s.pos += int64(m)
+		if err != nil {
+			return nil, err
+		}
+		return buf, nil
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, streamBufferSize))
+	m, err := io.CopyN(buf, s.Reader, int64(n))
+	s.pos += m
+	if err == io.EOF && m > 0 {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Skips over the given number of bytes.
+func (s *stream) skip(n uint32) error {
+	m, err := s.Discard(int(n))
+	s.pos += int64(m)
+	return err
+}
+
+// Reads an unsigned 32-bit varint.
+func (s *stream) readVarUint32() (uint32, error) {
+	var x uint32
+	var y uint32
+	for {
+		b, err := s.ReadByte()
+		if err == io.EOF && y > 0 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		s.pos += 1
+
+		x |= uint32(b&0x7F) << y
+		y += 7
+		if (b&0x80) == 0 || y == 35 {
+			break
+		}
+	}
+
+	return x, nil
+}
+
+// Determines whether or not the stream has reached the end of its input.
+func (s *stream) atEOF() bool {
+	_, err := s.Peek(1)
+	return err == io.EOF
+}
+
+// Converts running out of input into ErrTruncatedReplay. Used for reads that
+// happen partway through a message, where the input ending means the replay
+// was cut short.
+func truncated(err error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return ErrTruncatedReplay
+	}
+	return err
+}
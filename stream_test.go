@@ -0,0 +1,229 @@
+package manta
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// An outer message used to build synthetic replays.
+type testOuterMessage struct {
+	t    dota.EDemoCommands
+	tick uint32
+	msg  proto.Message
+}
+
+// Builds a synthetic Source 2 replay from the given outer messages.
+func makeTestReplay(msgs ...testOuterMessage) []byte {
+	buf := bytes.NewBuffer(nil)
+	buf.Write(magicSource2)
+	buf.Write(make([]byte, 8))
+
+	varint := make([]byte, binary.MaxVarintLen32)
+	for _, m := range msgs {
+		data := _proto_marshal(m.msg)
+		buf.Write(varint[:binary.PutUvarint(varint, uint64(m.t))])
+		buf.Write(varint[:binary.PutUvarint(varint, uint64(m.tick))])
+		buf.Write(varint[:binary.PutUvarint(varint, uint64(len(data)))])
+		buf.Write(data)
+	}
+
+	return buf.Bytes()
+}
+
+// A minimal replay containing a file header, a file info and a stop message.
+func makeTestReplayMinimal() []byte {
+	return makeTestReplay(
+		testOuterMessage{dota.EDemoCommands_DEM_FileHeader, 4294967295, &dota.CDemoFileHeader{
+			DemoFileStamp: proto.String("PBDEMS2\000"),
+			ServerName:    proto.String("test server"),
+		}},
+		testOuterMessage{dota.EDemoCommands_DEM_FileInfo, 100, &dota.CDemoFileInfo{
+			PlaybackTicks: proto.Int32(100),
+		}},
+		testOuterMessage{dota.EDemoCommands_DEM_Stop, 100, &dota.CDemoStop{}},
+	)
+}
+
+func TestStreamParser(t *testing.T) {
+	assert := assert.New(t)
+
+	// Read one byte at a time to make sure nothing depends on the whole
+	// replay being available up front.
+	r := iotest.OneByteReader(bytes.NewReader(makeTestReplayMinimal()))
+	parser, err := NewStreamParser(r)
+	assert.NoError(err)
+
+	serverName := ""
+	parser.Callbacks.OnCDemoFileHeader(func(m *dota.CDemoFileHeader) error {
+		serverName = m.GetServerName()
+		return nil
+	})
+
+	playbackTicks := int32(0)
+	parser.Callbacks.OnCDemoFileInfo(func(m *dota.CDemoFileInfo) error {
+		playbackTicks = m.GetPlaybackTicks()
+		return nil
+	})
+
+	assert.NoError(parser.Start())
+	assert.Equal("test server", serverName)
+	assert.Equal(int32(100), playbackTicks)
+	assert.Equal(uint32(100), parser.Tick)
+}
+
+func TestStreamParserPipe(t *testing.T) {
+	assert := assert.New(t)
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write(makeTestReplayMinimal())
+		pw.Close()
+	}()
+
+	parser, err := NewStreamParser(pr)
+	assert.NoError(err)
+	assert.NoError(parser.Start())
+}
+
+func TestStreamParserTruncated(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := makeTestReplayMinimal()
+	parser, err := NewParser(buf[:len(buf)-4])
+	assert.NoError(err)
+	assert.True(errors.Is(parser.Start(), ErrTruncatedReplay))
+}
+
+func TestStreamParserHugeSize(t *testing.T) {
+	assert := assert.New(t)
+
+	// A message claiming to be almost 4GB, followed by a little data.
+	buf := bytes.NewBuffer(nil)
+	buf.Write(magicSource2)
+	buf.Write(make([]byte, 8))
+	buf.Write(binary.AppendUvarint(nil, uint64(dota.EDemoCommands_DEM_Packet)))
+	buf.Write(binary.AppendUvarint(nil, 1))
+	buf.Write(binary.AppendUvarint(nil, 0xfffffff0))
+	buf.Write(make([]byte, 100))
+
+	parser, err := NewParser(buf.Bytes())
+	assert.NoError(err)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	assert.True(errors.Is(parser.Start(), ErrTruncatedReplay))
+	runtime.ReadMemStats(&after)
+	assert.Less(after.TotalAlloc-before.TotalAlloc, uint64(16<<20))
+}
+
+func TestStreamParserAllowTruncated(t *testing.T) {
+	assert := assert.New(t)
+
+	// Cut the replay partway through the final stop message.
+	buf := makeTestReplayMinimal()
+	parser, err := NewParser(buf[:len(buf)-1])
+	assert.NoError(err)
+
+	stopped := false
+	parser.AfterStopCallback = func() {
+		stopped = true
+	}
+
+	parser.AllowTruncated = true
+	assert.NoError(parser.Start())
+	assert.True(parser.Truncated)
+	assert.True(stopped)
+	assert.Equal(uint32(100), parser.Tick)
+}
+
+func TestStartContext(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMinimal())
+	assert.NoError(err)
+
+	// Cancel partway through the replay.
+	ctx, cancel := context.WithCancel(context.Background())
+	parser.Callbacks.OnCDemoFileHeader(func(m *dota.CDemoFileHeader) error {
+		cancel()
+		return nil
+	})
+
+	fileInfo := false
+	parser.Callbacks.OnCDemoFileInfo(func(m *dota.CDemoFileInfo) error {
+		fileInfo = true
+		return nil
+	})
+
+	assert.Equal(context.Canceled, parser.StartContext(ctx))
+	assert.False(fileInfo)
+}
+
+func TestStopFromGoroutine(t *testing.T) {
+	assert := assert.New(t)
+
+	buf := makeTestReplayMinimal()
+	stopped := make(chan struct{})
+
+	// Hold back the messages until the parser has been stopped.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write(buf[:16])
+		<-stopped
+		pw.Write(buf[16:])
+		pw.Close()
+	}()
+
+	parser, err := NewStreamParser(pr)
+	assert.NoError(err)
+
+	fileInfo := false
+	parser.Callbacks.OnCDemoFileInfo(func(m *dota.CDemoFileInfo) error {
+		fileInfo = true
+		return nil
+	})
+
+	go func() {
+		parser.Stop()
+		close(stopped)
+	}()
+
+	assert.NoError(parser.Start())
+	assert.False(fileInfo)
+}
+
+func TestStreamParserConcurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			parser, err := NewParser(makeTestReplayMinimal())
+			if err == nil {
+				err = parser.Start()
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(err)
+	}
+}
@@ -64,6 +64,33 @@ func (p *Parser) onCDemoStringTables(m *dota.CDemoStringTables) error {
 	return nil
 }
 
+// Replaces the items of existing string tables with a snapshot taken from
+// a CDemoFullPacket. Used to restore state when seeking.
+func (p *Parser) restoreStringTables(m *dota.CDemoStringTables) error {
+	for _, tt := range m.GetTables() {
+		t, ok := p.StringTables.GetTableByName(tt.GetTableName())
+		if !ok {
+			_debugf("skipping snapshot of unknown string table %s", tt.GetTableName())
+			continue
+		}
+
+		// Items in the snapshot are stored in index order.
+		t.Items = make(map[int32]*StringTableItem)
+		for i, item := range tt.GetItems() {
+			t.Items[int32(i)] = &StringTableItem{int32(i), item.GetStr(), item.GetData()}
+		}
+
+		// Apply the snapshot to baseline state
+		if t.name == "instancebaseline" {
+			if err := p.updateInstanceBaseline(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // Internal callback for CSVCMsg_CreateStringTable.
 // XXX TODO: This is currently using an artificial, internally crafted message.
 // This should be replaced with the real message once we have updated protos.
@@ -101,7 +128,10 @@ func (p *Parser) onCSVCMsg_CreateStringTable(m *dota.CSVCMsg_CreateStringTable)
 	}
 
 	// Parse the items out of the string table data
-	items := parseStringTable(buf, m.GetNumEntries(), t.userDataFixedSize, t.userDataSize)
+	items, err := parseStringTable(buf, m.GetNumEntries(), t.userDataFixedSize, t.userDataSize)
+	if err != nil {
+		return _errorf("unable to parse string table %s: %w", t.name, err)
+	}
 
 	// Insert the items into the table
 	for _, item := range items {
@@ -114,7 +144,7 @@ func (p *Parser) onCSVCMsg_CreateStringTable(m *dota.CSVCMsg_CreateStringTable)
 
 	// Apply the updates to baseline state
 	if t.name == "instancebaseline" {
-		p.updateInstanceBaseline()
+		return p.updateInstanceBaseline()
 	}
 
 	return nil
@@ -125,13 +155,16 @@ func (p *Parser) onCSVCMsg_UpdateStringTable(m *dota.CSVCMsg_UpdateStringTable)
 	// TODO: integrate
 	t, ok := p.StringTables.Tables[m.GetTableId()]
 	if !ok {
-		_panicf("missing string table %d", m.GetTableId())
+		return _errorf("missing string table %d", m.GetTableId())
 	}
 
 	_tracef("tick=%d name=%s changedEntries=%d buflen=%d", p.Tick, t.name, m.GetNumChangedEntries(), len(m.GetStringData()))
 
 	// Parse the updates out of the string table data
-	items := parseStringTable(m.GetStringData(), m.GetNumChangedEntries(), t.userDataFixedSize, t.userDataSize)
+	items, err := parseStringTable(m.GetStringData(), m.GetNumChangedEntries(), t.userDataFixedSize, t.userDataSize)
+	if err != nil {
+		return _errorf("unable to parse string table %s update: %w", t.name, err)
+	}
 
 	// Apply the updates to the parser state
 	for _, item := range items {
@@ -154,14 +187,14 @@ func (p *Parser) onCSVCMsg_UpdateStringTable(m *dota.CSVCMsg_UpdateStringTable)
 
 	// Apply the updates to baseline state
 	if t.name == "instancebaseline" {
-		p.updateInstanceBaseline()
+		return p.updateInstanceBaseline()
 	}
 
 	return nil
 }
 
 // Parse a string table data blob, returning a list of item updates.
-func parseStringTable(buf []byte, numUpdates int32, userDataFixed bool, userDataSize int32) (items []*StringTableItem) {
+func parseStringTable(buf []byte, numUpdates int32, userDataFixed bool, userDataSize int32) (items []*StringTableItem, err error) {
 	items = make([]*StringTableItem, 0)
 
 	// Create a reader for the buffer
@@ -176,7 +209,7 @@ func parseStringTable(buf []byte, numUpdates int32, userDataFixed bool, userData
 
 	// Some tables have no data
 	if len(buf) == 0 {
-		return items
+		return items, nil
 	}
 
 	// Loop through entries in the data structure
@@ -190,6 +223,11 @@ func parseStringTable(buf []byte, numUpdates int32, userDataFixed bool, userData
 	//
 	// Value may be omitted
 	for i := 0; i < int(numUpdates); i++ {
+		// Bail out if the buffer has run out.
+		if r.err != nil {
+			return nil, r.err
+		}
+
 		key := ""
 		value := []byte{}
 
@@ -256,5 +294,9 @@ func parseStringTable(buf []byte, numUpdates int32, userDataFixed bool, userData
 		items = append(items, &StringTableItem{index, key, value})
 	}
 
-	return items
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return items, nil
 }
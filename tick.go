@@ -0,0 +1,59 @@
+package manta
+
+// A function that handles the start or end of a tick.
+type tickHandler func(tick uint32)
+
+// Registers a handler called before any messages for a tick are dispatched.
+func (p *Parser) OnTickStart(fn func(tick uint32)) {
+	p.tickStartHandlers = append(p.tickStartHandlers, fn)
+}
+
+// Registers a handler called once all messages for a tick have been
+// dispatched, including all entity and string table updates. The handler is
+// called before the first message of the next tick, after the last tick of a
+// range or at the end of the replay. It isn't called for a tick cut short by
+// an error, Stop(), cancellation or a truncated replay.
+func (p *Parser) OnTickEnd(fn func(tick uint32)) {
+	p.tickEndHandlers = append(p.tickEndHandlers, fn)
+}
+
+// Moves on to the given tick if it isn't the one being dispatched, ending
+// the previous tick and starting the new one. Ticks before the start of a
+// range aren't offered to handlers.
+func (p *Parser) advanceTick(tick uint32) {
+	if p.tickOpen && tick == p.openTick {
+		return
+	}
+
+	p.endTick()
+
+	if p.skipping {
+		return
+	}
+
+	p.tickOpen = true
+	p.openTick = tick
+	for _, fn := range p.tickStartHandlers {
+		fn(tick)
+	}
+}
+
+// Ends the tick being dispatched once the replay or range has ended, returning
+// any panic raised by the handlers as an error.
+func (p *Parser) finishTick() (err error) {
+	defer p.recoverPanic(&err)
+	p.endTick()
+	return nil
+}
+
+// Ends the tick being dispatched, if any.
+func (p *Parser) endTick() {
+	if !p.tickOpen {
+		return
+	}
+
+	p.tickOpen = false
+	for _, fn := range p.tickEndHandlers {
+		fn(p.openTick)
+	}
+}
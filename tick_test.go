@@ -0,0 +1,118 @@
+package manta
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dotabuff/manta/dota"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTickHandlers(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplay(
+		testOuterMessage{dota.EDemoCommands_DEM_FileHeader, 4294967295, &dota.CDemoFileHeader{
+			DemoFileStamp: proto.String("PBDEMS2\000"),
+		}},
+		testOuterMessage{dota.EDemoCommands_DEM_Packet, 10, &dota.CDemoPacket{}},
+		testOuterMessage{dota.EDemoCommands_DEM_Packet, 10, &dota.CDemoPacket{}},
+		testOuterMessage{dota.EDemoCommands_DEM_Packet, 20, &dota.CDemoPacket{}},
+		testOuterMessage{dota.EDemoCommands_DEM_Stop, 20, &dota.CDemoStop{}},
+	))
+	assert.NoError(err)
+
+	events := make([]string, 0)
+	parser.OnTickStart(func(tick uint32) {
+		events = append(events, _sprintf("start %d", tick))
+	})
+	parser.OnTickEnd(func(tick uint32) {
+		events = append(events, _sprintf("end %d", tick))
+	})
+	parser.Callbacks.OnCDemoPacket(func(m *dota.CDemoPacket) error {
+		events = append(events, _sprintf("packet %d", parser.Tick))
+		return nil
+	})
+	parser.AfterStopCallback = func() {
+		events = append(events, "stop")
+	}
+
+	assert.NoError(parser.Start())
+	assert.Equal([]string{
+		"start 0", "end 0",
+		"start 10", "packet 10", "packet 10", "end 10",
+		"start 20", "packet 20", "end 20",
+		"stop",
+	}, events)
+}
+
+func TestTickHandlersRange(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewStreamParser(bytes.NewBuffer(makeTestReplaySeekable()))
+	assert.NoError(err)
+
+	ticks := make([]uint32, 0)
+	parser.OnTickEnd(func(tick uint32) {
+		ticks = append(ticks, tick)
+	})
+
+	assert.NoError(parser.StartRange(15, 35))
+	assert.Equal([]uint32{20, 30}, ticks)
+}
+
+func TestTickHandlersCutShort(t *testing.T) {
+	assert := assert.New(t)
+
+	scenarios := []struct {
+		fn       func(p *Parser) error
+		hasError bool
+	}{
+		{func(p *Parser) error { p.Stop(); return nil }, false},
+		{func(p *Parser) error { return _errorf("failed") }, true},
+		{func(p *Parser) error { panic("failed") }, true},
+	}
+
+	for _, s := range scenarios {
+		parser, err := NewParser(makeTestReplay(
+			testOuterMessage{dota.EDemoCommands_DEM_Packet, 10, &dota.CDemoPacket{}},
+			testOuterMessage{dota.EDemoCommands_DEM_Packet, 20, &dota.CDemoPacket{}},
+			testOuterMessage{dota.EDemoCommands_DEM_Packet, 20, &dota.CDemoPacket{}},
+		))
+		assert.NoError(err)
+
+		ticks := make([]uint32, 0)
+		parser.OnTickEnd(func(tick uint32) {
+			ticks = append(ticks, tick)
+		})
+		parser.Callbacks.OnCDemoPacket(func(m *dota.CDemoPacket) error {
+			if parser.Tick == 20 {
+				return s.fn(parser)
+			}
+			return nil
+		})
+
+		// The tick cut short isn't reported as having ended.
+		assert.Equal(s.hasError, parser.Start() != nil)
+		assert.Equal([]uint32{10}, ticks)
+	}
+}
+
+func TestTickHandlersEndPanic(t *testing.T) {
+	assert := assert.New(t)
+
+	parser, err := NewParser(makeTestReplayMinimal())
+	assert.NoError(err)
+
+	parser.OnTickEnd(func(tick uint32) {
+		if tick == 100 {
+			panic("failed")
+		}
+	})
+
+	err = parser.Start()
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "failed")
+	}
+}
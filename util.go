@@ -9,13 +9,17 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/golang/protobuf/proto"
 )
 
 var debugMode, traceMode, fixturesMode bool
-var debugLevel, testLevel uint // Test level refers to the inline-test level which runs additional checks on the data
+
+// The level of debug output from _debugfl. Accessed atomically, as it may be
+// changed while parsers are running.
+var debugLevel uint32
 
 func init() {
 	if os.Getenv("DEBUG") != "" {
@@ -43,9 +47,19 @@ func atoi32(s string) (int32, error) {
 	return int32(n), nil
 }
 
+// Sets the level of debug output from _debugfl.
+func setDebugLevel(level uint) {
+	atomic.StoreUint32(&debugLevel, uint32(level))
+}
+
+// Returns the level of debug output from _debugfl.
+func getDebugLevel() uint {
+	return uint(atomic.LoadUint32(&debugLevel))
+}
+
 // printf with debug level
 func _debugfl(level uint, format string, args ...interface{}) {
-	if level <= debugLevel {
+	if level <= getDebugLevel() {
 		args = append([]interface{}{_caller(2)}, args...)
 		fmt.Printf("%s: "+format+"\n", args...)
 	}